@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/faradayfan/chore-distributor/internal/config"
+	"github.com/faradayfan/chore-distributor/internal/distributor"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Work with the configuration file",
+}
+
+var configCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check that every chore this week can be assigned",
+	Long: `Load and validate the configuration file, then distribute this week's
+chores the way 'distribute' would, without sending, saving or recording
+anything.
+
+If any chore can't be assigned, lists why, and the smallest changes to the
+config that would get every chore assigned, such as raising someone's
+EffortCapacity or dropping a chore, and exits with an error.`,
+	Example: `  # Check the default config file
+  chore-distributor config check
+
+  # Check with the optimal strategy and a particular seed
+  chore-distributor config check --strategy optimal --seed 8675309`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkConfig(cmd)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configCheckCmd)
+
+	configCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "chores_config.json",
+		"Path to the JSON configuration file")
+	configCheckCmd.Flags().StringVar(&strategyName, "strategy", "",
+		"Distribution strategy to check with (overrides config file)")
+	configCheckCmd.Flags().Uint64Var(&seed, "seed", 0,
+		"Seed for the random number generator, any number but 0 (default: random)")
+}
+
+func checkConfig(cmd *cobra.Command) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	runSeed := selectSeed(cmd)
+	strategy := selectStrategy(cfg)
+	thisWeek := prepareWeek(cmd, cfg, true)
+
+	fmt.Printf("✓ %s is valid: %d chore(s) this week for %d people", configPath, len(thisWeek.Chores), len(thisWeek.Present))
+	if len(thisWeek.Away) > 0 {
+		fmt.Printf(" (%d away)", len(thisWeek.Away))
+	}
+	fmt.Println()
+
+	result := strategy.Distribute(thisWeek.Chores, thisWeek.Present, distributor.NewRand(runSeed), thisWeek.Opts)
+	if len(result.Unassigned) == 0 {
+		fmt.Printf("✓ Every chore can be assigned (%s, seed %d)\n", strategy.Name(), runSeed)
+		return
+	}
+
+	fmt.Printf("✗ %d chore(s) can't be assigned (%s, seed %d):\n", len(result.Unassigned), strategy.Name(), runSeed)
+	for _, u := range distributor.SortedUnassigned(result.Unassigned) {
+		fmt.Printf("  - %s: %s\n", u.Chore.Label(), u.Reason)
+		for _, e := range u.Excluded {
+			fmt.Printf("      %s: %s\n", e.Person, e.Reason)
+		}
+	}
+	distributor.PrintDiagnosis(os.Stdout, distributor.Diagnose(strategy, thisWeek.Chores, thisWeek.Present, runSeed, thisWeek.Opts))
+	os.Exit(1)
+}
@@ -4,12 +4,18 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/faradayfan/chore-distributor/internal/availability"
+	"github.com/faradayfan/chore-distributor/internal/calendar"
 	"github.com/faradayfan/chore-distributor/internal/config"
 	"github.com/faradayfan/chore-distributor/internal/distributor"
+	"github.com/faradayfan/chore-distributor/internal/history"
 	"github.com/faradayfan/chore-distributor/internal/models"
 	"github.com/faradayfan/chore-distributor/internal/notes"
+	"github.com/faradayfan/chore-distributor/internal/recurrence"
 	"github.com/faradayfan/chore-distributor/internal/sms"
 	"github.com/spf13/cobra"
 )
@@ -23,6 +29,18 @@ var (
 	confirm           bool
 	smsTemplatePath   string
 	notesTemplatePath string
+	seed              uint64
+	strict            bool
+	strategyName      string
+	record            bool
+	carryOverWeeks    int
+	calendarPaths     []string
+	mode              string
+	bidsPath          string
+	budget            int
+	explain           bool
+	planWeeks         int
+	pinArgs           []string
 )
 
 var distributeCmd = &cobra.Command{
@@ -31,13 +49,44 @@ var distributeCmd = &cobra.Command{
 	Long: `Distribute chores among family members based on the configuration file.
 
 The distribution algorithm:
-  1. Loads chores and people from the JSON configuration file
-  2. Shuffles chores and sorts by earning amount (highest first)
-  3. Assigns each chore to the person with the lowest current earnings
-     who has available capacity
-  4. Displays the final distribution
-  5. Optionally sends iMessage notifications to each person (macOS only)
-  6. Optionally saves to an Apple Note (macOS only)`,
+  1. Loads chores and people from the JSON configuration file, expanding
+     recurring chores into one chore per day they are due this week
+  2. Leaves out anyone away all week, and scales down the capacity of
+     anyone away for part of it or busy on their calendars (.ics files)
+  3. Shuffles chores and sorts by earning amount (highest first)
+  4. Assigns the chores using the selected strategy, respecting each
+     person's effort capacity and each chore's eligibility rules, and
+     scheduling chores on days of the week where configured:
+       greedy       each chore goes to the person with the lowest current
+                    earnings (default)
+       round-robin  chores are dealt out in turn like cards
+       optimal      searches for the assignment with the smallest
+                    earnings gap
+       preference   gives people chores they love while keeping the
+                    earnings gap within preferenceTolerance
+     With --mode draft, people instead pick chores in turns, lowest
+     earnings first and reversing every round, and the strategy assigns
+     whatever is left once nobody can pick any more. With --mode auction,
+     everyone names the least they'd take for each chore, and each chore
+     goes to the lowest bid, at that price, within the weekly budget
+  5. Displays the final distribution and the seed used to generate it
+  6. Reports any chore nobody had capacity for or was eligible for, and
+     the smallest changes to the config that would get it assigned
+  7. Optionally sends iMessage notifications to each person who is around
+     (macOS only)
+  8. Optionally saves to an Apple Note (macOS only)
+
+Distributions that are sent, saved to a note, or run with --record are
+added to a history file next to the config file (see 'history list').
+
+Passing the printed seed back with --seed regenerates exactly the same
+distribution from the same configuration file.
+
+With --weeks, the next several weeks are planned at once and printed as a
+grid of who does each chore each week. Each week's earnings carry over to
+the next and chores rotate across the plan, so totals even out and everyone
+gets each chore about as often. With --record, every week is saved to the
+history.`,
 	Example: `  # Use default config file (chores_config.json)
   chore-distributor distribute
 
@@ -60,44 +109,153 @@ The distribution algorithm:
   chore-distributor distribute --sms --note "Chore History" --confirm
 
   # Preview all actions without sending/saving (dry run)
-  chore-distributor distribute --sms --note "Chore History" --dry-run`,
+  chore-distributor distribute --sms --note "Chore History" --dry-run
+
+  # Fail without sending or saving if any chore could not be assigned
+  chore-distributor distribute --sms --note "Chore History" --strict
+
+  # Search for the assignment with the smallest earnings gap
+  chore-distributor distribute --strategy optimal
+
+  # Make up for earnings imbalances over the last 4 saved weeks
+  chore-distributor distribute --carry-over 4
+
+  # Reduce Alice's capacity by the time she is busy on her calendar
+  chore-distributor distribute --calendar Alice=alice.ics -v
+
+  # Let everyone pick their own chores in turns
+  chore-distributor distribute --mode draft
+
+  # Auction the chores off from a bids file, paying out at most $40
+  chore-distributor distribute --mode auction --bids bids.json --budget 40
+
+  # Show why each chore went to whoever got it
+  chore-distributor distribute --explain
+
+  # Plan the next 4 weeks, rotating chores and balancing earnings across them
+  chore-distributor distribute --weeks 4
+
+  # Plan the next 4 weeks and save every week to the history
+  chore-distributor distribute --weeks 4 --record
+
+  # Keep the kitchen with John and distribute everything else
+  chore-distributor distribute --pin Kitchen=John
+
+  # Regenerate a previous distribution from its seed
+  chore-distributor distribute --seed 8675309`,
 	Run: func(cmd *cobra.Command, args []string) {
-		runDistribute()
+		runDistribute(cmd)
 	},
 }
 
-func runDistribute() {
+func runDistribute(cmd *cobra.Command) {
 	cfg, err := config.Load(configPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 		os.Exit(1)
 	}
 
+	runSeed := selectSeed(cmd)
+
+	strategy := selectStrategy(cfg)
+	switch mode {
+	case "auto", "draft", "auction":
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown mode %q (want auto, draft or auction)\n", mode)
+		os.Exit(1)
+	}
+	if !cmd.Flags().Changed("budget") {
+		budget = cfg.AuctionBudget
+	}
+	if planWeeks < 1 {
+		fmt.Fprintf(os.Stderr, "Error: --weeks must be at least 1, got %d\n", planWeeks)
+		os.Exit(1)
+	}
+	if planWeeks > 1 {
+		runPlan(cmd, cfg, strategy, runSeed)
+		return
+	}
+
+	pins, err := distributor.ParsePins(pinArgs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	thisWeek := prepareWeek(cmd, cfg, mode != "auction")
+	chores, present, away, distOpts := thisWeek.Chores, thisWeek.Present, thisWeek.Away, thisWeek.Opts
+
+	// Bids are collected once, so a retry re-runs the auction with the same bids
+	var bids distributor.Bids
+	if mode == "auction" {
+		if bidsPath != "" {
+			bids, err = config.LoadBids(bidsPath, cfg)
+		} else {
+			bids, err = distributor.PromptBids(os.Stdin, os.Stdout, chores, present, distOpts)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	var result *models.DistributionResult
 	for {
-		for i := range cfg.People {
-			cfg.People[i].Chores = []models.Chore{}
-			cfg.People[i].TotalDifficulty = 0
-			cfg.People[i].TotalEarned = 0
-
-			// Recalculate from pre-assigned chores
-			for _, chore := range cfg.People[i].PreAssignedChores {
-				cfg.People[i].TotalDifficulty += chore.Difficulty
-				cfg.People[i].TotalEarned += chore.Earned
-			}
+		// Every distribution keeps a trace of its decisions, for --explain now and the
+		// explain command later
+		trace := &distributor.Trace{}
+		distOpts.Trace = trace
+
+		// Pinned chores are handed out first, and only the rest are distributed
+		rest, pinned, err := distributor.PinChores(chores, present, pins, distOpts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
 
-		cfg.People = distributor.Distribute(cfg.Chores, cfg.People)
+		switch mode {
+		case "draft":
+			result, err = distributor.Draft(os.Stdin, os.Stdout, rest, pinned, distributor.NewRand(runSeed), distOpts, strategy)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading picks: %v\n", err)
+				os.Exit(1)
+			}
+		case "auction":
+			result = distributor.Auction(rest, pinned, distributor.NewRand(runSeed), distOpts, bids, budget)
+		default:
+			result = strategy.Distribute(rest, pinned, distributor.NewRand(runSeed), distOpts)
+		}
+		result.Seed = runSeed
+		result.Away = away
+		result.Pricing = thisWeek.Pricing
+		result.Decisions = trace.Decisions
 
 		opts := distributor.PrintOptions{
 			Verbose: verbose,
 		}
-		distributor.PrintDistribution(os.Stdout, cfg.People, opts)
+		distributor.PrintDistribution(os.Stdout, result, opts)
+		if len(pins) > 0 {
+			fmt.Printf("Pinned: %s\n", joinPins(pins))
+		}
+		// An auction's unassigned chores are down to the bids and budget instead
+		if len(result.Unassigned) > 0 && mode != "auction" {
+			distributor.PrintDiagnosis(os.Stdout, distributor.Diagnose(strategy, rest, pinned, runSeed, distOpts))
+		}
+		if explain {
+			fmt.Printf("\n=== Why Each Chore Went Where ===\n\n")
+			distributor.PrintExplanation(os.Stdout, result.Decisions, "")
+		}
 
 		if confirm && (noteName != "" || sendSMS) && !dryRun {
-			result := promptConfirmation()
-			switch result {
+			choice := promptPinConfirmation(result, chores, &pins)
+			switch choice {
 			case "retry":
-				fmt.Println("--- Retrying distribution ---")
+				if len(pins) > 0 {
+					fmt.Printf("--- Retrying distribution, keeping %s ---\n", joinPins(pins))
+				} else {
+					fmt.Println("--- Retrying distribution ---")
+				}
+				runSeed = distributor.NewSeed()
 				continue
 			case "cancel":
 				fmt.Println("Cancelled.")
@@ -109,6 +267,29 @@ func runDistribute() {
 		break
 	}
 
+	if len(result.Unassigned) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %d chore(s) could not be assigned\n", len(result.Unassigned))
+	}
+
+	if strict && len(result.Unassigned) > 0 {
+		if sendSMS && cfg.ParentContact != "" {
+			sender := sms.NewSender(dryRun, "")
+			if err := sender.SendUnassignedAlert(cfg.ParentContact, result); err != nil {
+				fmt.Fprintf(os.Stderr, "Error sending unassigned chore alert: %v\n", err)
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Error: not saving or sending an incomplete distribution (--strict)\n")
+		os.Exit(1)
+	}
+
+	// The week is only saved to the history once the note is written and everyone has
+	// been sent their chores, since the history decides rotation, carry-over and when
+	// recurring chores are due
+	notSaved := ""
+	if !dryRun {
+		notSaved = " (not saved to the history)"
+	}
+
 	if noteName != "" {
 		if !notes.IsSupported() && !dryRun {
 			fmt.Fprintf(os.Stderr, "Error: Apple Notes is only supported on macOS\n")
@@ -123,12 +304,13 @@ func runDistribute() {
 
 		fmt.Println("\n--- Saving to Apple Notes ---")
 		writer := notes.NewWriter(noteName, dryRun, templatePath)
-		if err := writer.PrependChoreList(cfg.People, verbose); err != nil {
-			fmt.Fprintf(os.Stderr, "Error saving to Notes: %v\n", err)
+		if err := writer.PrependChoreList(result, verbose); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving to Notes%s: %v\n", notSaved, err)
 			os.Exit(1)
 		}
 	}
 
+	var sender *sms.Sender
 	if sendSMS {
 		if !sms.IsSupported() && !dryRun {
 			fmt.Fprintf(os.Stderr, "Error: iMessage is only supported on macOS\n")
@@ -142,12 +324,197 @@ func runDistribute() {
 		}
 
 		fmt.Println("\n--- Sending iMessage Notifications ---")
-		sender := sms.NewSender(dryRun, templatePath)
-		if err := sender.SendChoreAssignments(cfg.People, verbose); err != nil {
-			fmt.Fprintf(os.Stderr, "Error sending messages: %v\n", err)
+		sender = sms.NewSender(dryRun, templatePath)
+		if err := sender.SendChoreAssignments(result, verbose); err != nil {
+			fmt.Fprintf(os.Stderr, "Error sending messages%s: %v\n", notSaved, err)
+			os.Exit(1)
+		}
+	}
+
+	if !dryRun && (record || sendSMS || noteName != "") {
+		store := history.NewStore(historyPath(cfg))
+		entry, err := store.Append(history.NewEntry(result, cfg.Hash, time.Now()))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving to history: %v\n", err)
 			os.Exit(1)
 		}
+		fmt.Printf("\n✓ Saved as distribution #%d in %s\n", entry.ID, store.Path)
 	}
+
+	if sender != nil && cfg.ParentContact != "" {
+		if err := sender.SendUnassignedAlert(cfg.ParentContact, result); err != nil {
+			fmt.Fprintf(os.Stderr, "Error sending unassigned chore alert: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// selectSeed returns the seed given with --seed, or else a new random one. A seed of 0
+// means a distribution has no seed, so it is never shown, and --seed 0 is rejected.
+func selectSeed(cmd *cobra.Command) uint64 {
+	if !cmd.Flags().Changed("seed") {
+		return distributor.NewSeed()
+	}
+	if seed == 0 {
+		fmt.Fprintf(os.Stderr, "Error: --seed must not be 0\n")
+		os.Exit(1)
+	}
+	return seed
+}
+
+// selectStrategy returns the strategy named by --strategy, or else the config, or
+// else the default.
+func selectStrategy(cfg *models.Config) distributor.Strategy {
+	// Use CLI flag if provided, otherwise use config value
+	name := strategyName
+	if name == "" {
+		name = cfg.Strategy
+	}
+	if name == "" {
+		name = distributor.DefaultStrategy
+	}
+	strategy, err := distributor.Lookup(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	return strategy
+}
+
+// prepareWeek expands the config into the week starting today: the chores due, with
+// any surge bonuses if surge is set, the people around and away, and the options to
+// distribute with, including rotation and carry-over from the history.
+func prepareWeek(cmd *cobra.Command, cfg *models.Config, surge bool) distributor.Week {
+	return prepareWeekOf(cmd, cfg, time.Now(), loadHistory(cfg), 0, surge)
+}
+
+// loadHistory loads the saved distributions for the config.
+func loadHistory(cfg *models.Config) []history.Entry {
+	entries, err := history.NewStore(historyPath(cfg)).Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading history: %v\n", err)
+		os.Exit(1)
+	}
+	return entries
+}
+
+// prepareWeekOf is prepareWeek for the week starting weekStart, given the entries
+// before it. The last planned entries are weeks planned ahead of it, which always
+// count toward rotation and carry-over, on top of the weeks the config looks back
+// over.
+func prepareWeekOf(cmd *cobra.Command, cfg *models.Config, weekStart time.Time, entries []history.Entry, planned int, surge bool) distributor.Week {
+	chores, err := recurrence.Expand(cfg.Chores, weekStart, history.LastDistributed(entries, weekStart))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	distOpts := distributor.DefaultOptions()
+	distOpts.Date = weekStart
+	distOpts.WeekPlan = cfg.WeekPlan
+	distOpts.NeverPair = cfg.NeverPair
+	distOpts.Together = cfg.Together
+	distOpts.Apart = cfg.Apart
+	if cfg.PreferenceTolerance != nil {
+		distOpts.Tolerance = *cfg.PreferenceTolerance
+	}
+	if cfg.Weights != nil {
+		distOpts.Weights = *cfg.Weights
+	}
+	var rotation models.Rotation
+	if cfg.Rotation != nil {
+		rotation = *cfg.Rotation
+	}
+	// Chores rotate within a plan even if they don't otherwise
+	if planned > 0 && rotation.Penalty == 0 {
+		rotation.Penalty = distributor.PlanRotationPenalty
+	}
+	if weeks := rotation.Weeks + planned; weeks > 0 {
+		distOpts.RecentChores = history.RecentChores(entries, weekStart, weeks)
+		distOpts.RotationPenalty = rotation.Penalty
+	}
+
+	// Use CLI flag if provided, otherwise use config value
+	if !cmd.Flags().Changed("carry-over") && cfg.CarryOver != nil {
+		carryOverWeeks = cfg.CarryOver.Weeks
+	}
+	var carry map[string]int
+	if weeks := carryOverWeeks + planned; weeks > 0 {
+		carry = history.CarryOver(entries, weekStart, weeks)
+	}
+	for i := range cfg.People {
+		cfg.People[i].CarryOver = carry[cfg.People[i].Name]
+	}
+
+	// People away all week get no chores, and everyone else only gets chores on the
+	// days they are around
+	present, away := availability.ForWeek(cfg.People, weekStart)
+	if err := applyCalendars(cfg, present, weekStart); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Chores nobody wants earn a surge bonus
+	var pricing []models.PriceChange
+	if cfg.Surge != nil && surge {
+		chores, pricing = distributor.Surge(chores, present, history.UnassignedWeeks(entries, weekStart, cfg.Surge.Weeks), *cfg.Surge)
+	}
+
+	return distributor.Week{
+		Chores:  chores,
+		Present: present,
+		Away:    away,
+		Opts:    distOpts,
+		Pricing: pricing,
+	}
+}
+
+// historyPath returns the history file for the loaded config: the configured
+// historyPath if set, otherwise a file next to the config file.
+func historyPath(cfg *models.Config) string {
+	if cfg.HistoryPath != "" {
+		return cfg.HistoryPath
+	}
+	return history.PathFor(configPath)
+}
+
+// applyCalendars reduces the capacity of each person by the busy time in the week on
+// their calendars: those in the config plus any given with --calendar NAME=FILE.
+func applyCalendars(cfg *models.Config, people []models.Person, weekStart time.Time) error {
+	paths := make(map[string][]string)
+	for _, person := range cfg.People {
+		paths[person.Name] = append(paths[person.Name], person.Calendars...)
+	}
+	for _, arg := range calendarPaths {
+		name, path, ok := strings.Cut(arg, "=")
+		if !ok || path == "" {
+			return fmt.Errorf("invalid --calendar %q (want NAME=FILE)", arg)
+		}
+		if _, known := paths[name]; !known {
+			return fmt.Errorf("--calendar %q: no person named %q", arg, name)
+		}
+		paths[name] = append(paths[name], path)
+	}
+
+	hours, err := calendar.ParseHours(cfg.CalendarHours)
+	if err != nil {
+		return err
+	}
+	for i, person := range people {
+		if len(paths[person.Name]) == 0 {
+			continue
+		}
+		var events []calendar.Event
+		for _, path := range paths[person.Name] {
+			loaded, err := calendar.Load(path, weekStart.Location())
+			if err != nil {
+				return err
+			}
+			events = append(events, loaded...)
+		}
+		people[i] = calendar.Apply(person, events, weekStart, hours)
+	}
+	return nil
 }
 
 func promptConfirmation() string {
@@ -176,6 +543,77 @@ func promptConfirmation() string {
 	}
 }
 
+// promptPinConfirmation is promptConfirmation for a single week, where chores can also
+// be pinned to whoever has them in the result, or unpinned, before retrying. chores are
+// what the result was distributed from.
+func promptPinConfirmation(result *models.DistributionResult, chores []models.Chore, pins *[]distributor.Pin) string {
+	reader := bufio.NewReader(os.Stdin)
+
+	for {
+		fmt.Print("\n[C]onfirm, [R]etry, [P]in <chore>, [U]npin <chore>, or [A]bort? ")
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+			return "cancel"
+		}
+
+		command, chore, _ := strings.Cut(strings.TrimSpace(input), " ")
+		chore = strings.TrimSpace(chore)
+
+		switch strings.ToLower(command) {
+		case "c", "confirm":
+			return "confirm"
+		case "r", "retry":
+			return "retry"
+		case "a", "abort", "cancel":
+			return "cancel"
+		case "p", "pin", "keep":
+			pinChore(result, chores, pins, chore)
+		case "u", "unpin":
+			unpinChore(pins, chore)
+		default:
+			fmt.Println("Please enter C (confirm), R (retry), P <chore> (pin), U <chore> (unpin), or A (abort)")
+		}
+	}
+}
+
+// pinChore pins the named chore to whoever has it in the result, in place of any pin
+// it already had, so a retry keeps it where it is.
+func pinChore(result *models.DistributionResult, chores []models.Chore, pins *[]distributor.Pin, chore string) {
+	if chore == "" {
+		fmt.Println(`Please name the chore to pin, e.g. "pin Kitchen"`)
+		return
+	}
+	found := distributor.PinsFor(result, chores, chore)
+	if len(found) == 0 {
+		fmt.Printf("Nobody has %q in this distribution\n", chore)
+		return
+	}
+	*pins = slices.DeleteFunc(*pins, func(p distributor.Pin) bool { return strings.EqualFold(p.Chore, chore) })
+	*pins = append(*pins, found...)
+	fmt.Printf("Pinned %s\n", joinPins(found))
+}
+
+// unpinChore lets the named chore go to anyone again on a retry.
+func unpinChore(pins *[]distributor.Pin, chore string) {
+	n := len(*pins)
+	*pins = slices.DeleteFunc(*pins, func(p distributor.Pin) bool { return strings.EqualFold(p.Chore, chore) })
+	if len(*pins) == n {
+		fmt.Printf("%q isn't pinned\n", chore)
+		return
+	}
+	fmt.Printf("Unpinned %s\n", chore)
+}
+
+// joinPins lists pins, such as "Kitchen → John, Garage → John + Mary".
+func joinPins(pins []distributor.Pin) string {
+	var parts []string
+	for _, pin := range pins {
+		parts = append(parts, pin.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
 func init() {
 	rootCmd.AddCommand(distributeCmd)
 
@@ -188,11 +626,35 @@ func init() {
 	distributeCmd.Flags().StringVarP(&noteName, "note", "o", "",
 		"Save chore list to an Apple Note with this name (macOS only). Creates note if it doesn't exist.")
 	distributeCmd.Flags().BoolVarP(&confirm, "confirm", "i", false,
-		"Prompt for confirmation before sending messages and saving to notes")
+		"Prompt for confirmation before sending messages and saving to notes, with the option to pin chores and retry")
 	distributeCmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false,
 		"Preview actions without actually sending messages or saving to notes")
 	distributeCmd.Flags().StringVar(&smsTemplatePath, "sms-template", "",
 		"Path to custom Go template for SMS messages (overrides config file)")
 	distributeCmd.Flags().StringVar(&notesTemplatePath, "notes-template", "",
 		"Path to custom Go template for Apple Notes (overrides config file)")
+	distributeCmd.Flags().Uint64Var(&seed, "seed", 0,
+		"Seed for the random number generator, any number but 0 (default: random). Reuse a printed seed to reproduce a distribution.")
+	distributeCmd.Flags().StringVar(&strategyName, "strategy", "",
+		"Distribution strategy: "+strings.Join(distributor.Strategies(), ", ")+" (overrides config file, default: "+distributor.DefaultStrategy+")")
+	distributeCmd.Flags().StringVar(&mode, "mode", "auto",
+		"How chores are handed out: auto (by the strategy), draft (people pick in turns, the strategy assigns what is left) or auction (lowest bid wins)")
+	distributeCmd.Flags().BoolVar(&explain, "explain", false,
+		"Explain why each chore went to whoever got it")
+	distributeCmd.Flags().StringArrayVar(&pinArgs, "pin", nil,
+		"Keep a chore with a person, as CHORE=PERSON (CHORE=PERSON+PERSON for a team chore), and distribute the rest (repeatable)")
+	distributeCmd.Flags().IntVar(&planWeeks, "weeks", 1,
+		"Plan this many weeks ahead, balancing earnings and rotating chores across them")
+	distributeCmd.Flags().StringVar(&bidsPath, "bids", "",
+		"With --mode auction, read bids from this JSON file instead of asking for them")
+	distributeCmd.Flags().IntVar(&budget, "budget", 0,
+		"With --mode auction, the most to pay out this week (0 for no limit, overrides config file)")
+	distributeCmd.Flags().IntVar(&carryOverWeeks, "carry-over", 0,
+		"Start each person from their earnings surplus or deficit over this many past weeks (0 to disable, overrides config file)")
+	distributeCmd.Flags().StringArrayVar(&calendarPaths, "calendar", nil,
+		"Reduce a person's capacity by their busy time in a local .ics file, as NAME=FILE (repeatable, adds to the config file)")
+	distributeCmd.Flags().BoolVar(&record, "record", false,
+		"Save the distribution to the history file (automatic with --sms or --note)")
+	distributeCmd.Flags().BoolVar(&strict, "strict", false,
+		"Exit with an error instead of sending messages or saving to notes if any chore could not be assigned")
 }
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/faradayfan/chore-distributor/internal/distributor"
+	"github.com/faradayfan/chore-distributor/internal/history"
+	"github.com/spf13/cobra"
+)
+
+var (
+	explainID int
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <chore>",
+	Short: "Explain why a chore went to whoever got it",
+	Long: `Explain how a chore was handed out in a saved distribution: who could
+take it, where everyone's earnings and capacity stood at the time, why
+anyone else was left out, and whether it came down to a random tie-break.
+
+The most recent saved distribution is used unless --id is given. Weeks
+saved ahead of time from a plan are skipped.`,
+	Example: `  # Why did the bathroom go where it did last time?
+  chore-distributor explain Bathroom
+
+  # The same for distribution #3
+  chore-distributor explain Bathroom --id 3`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		explainChore(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+
+	explainCmd.Flags().StringVarP(&configPath, "config", "c", "chores_config.json",
+		"Path to the JSON configuration file")
+	explainCmd.Flags().IntVar(&explainID, "id", 0,
+		"The saved distribution to explain (default: the most recent, not counting planned weeks)")
+}
+
+func explainChore(chore string) {
+	store := loadHistoryStore()
+
+	var entry history.Entry
+	if explainID > 0 {
+		var err error
+		if entry, err = store.Get(explainID); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		entries, err := store.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading history: %v\n", err)
+			os.Exit(1)
+		}
+		// Planned weeks haven't been distributed yet
+		var ok bool
+		if entry, ok = history.Latest(entries); !ok {
+			fmt.Fprintf(os.Stderr, "Error: no distributions saved in %s\n", store.Path)
+			os.Exit(1)
+		}
+	}
+
+	if len(entry.Decisions) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: distribution #%d was saved without explanations\n", entry.ID)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Distribution #%d from %s\n\n", entry.ID, entry.Timestamp.Local().Format("Monday, January 2, 2006 at 3:04 PM"))
+	if !distributor.PrintExplanation(os.Stdout, entry.Result().Decisions, chore) {
+		fmt.Fprintf(os.Stderr, "Error: %q wasn't handed out in distribution #%d\n", chore, entry.ID)
+		os.Exit(1)
+	}
+}
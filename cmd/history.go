@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/faradayfan/chore-distributor/internal/config"
+	"github.com/faradayfan/chore-distributor/internal/distributor"
+	"github.com/faradayfan/chore-distributor/internal/history"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyLimit int
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "View past distributions",
+	Long: `View distributions saved to the history file.
+
+A distribution is saved when it is sent with --sms, saved with --note, or
+run with --record. The history file lives next to the config file
+(chores_config.json keeps its history in chores_config.history.jsonl)
+unless "historyPath" is set in the config.`,
+}
+
+var historyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved distributions",
+	Example: `  # List the saved distributions for the default config
+  chore-distributor history list
+
+  # Only the last 4
+  chore-distributor history list --limit 4`,
+	Run: func(cmd *cobra.Command, args []string) {
+		listHistory()
+	},
+}
+
+var historyShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show a saved distribution",
+	Example: `  # Show distribution #3 with difficulty and capacity information
+  chore-distributor history show 3 --verbose`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid distribution ID %q\n", args[0])
+			os.Exit(1)
+		}
+		showHistory(id)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.AddCommand(historyListCmd)
+	historyCmd.AddCommand(historyShowCmd)
+
+	historyCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "chores_config.json",
+		"Path to the JSON configuration file")
+	historyListCmd.Flags().IntVar(&historyLimit, "limit", 0,
+		"Only list the most recent distributions (0 for all)")
+	historyShowCmd.Flags().BoolVarP(&verbose, "verbose", "v", false,
+		"Show difficulty and capacity information")
+}
+
+func loadHistoryStore() *history.Store {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	return history.NewStore(historyPath(cfg))
+}
+
+func listHistory() {
+	store := loadHistoryStore()
+	entries, err := store.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading history: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No distributions saved in %s\n", store.Path)
+		return
+	}
+
+	if historyLimit > 0 && len(entries) > historyLimit {
+		entries = entries[len(entries)-historyLimit:]
+	}
+
+	fmt.Printf("%-4s  %-16s  %-11s  %-20s  %-8s  %s\n", "ID", "Date", "Strategy", "Seed", "Config", "Earnings")
+	for _, entry := range entries {
+		var earnings []string
+		for _, p := range entry.People {
+			earnings = append(earnings, fmt.Sprintf("%s $%d", p.Name, p.TotalEarned))
+		}
+		if len(entry.Unassigned) > 0 {
+			earnings = append(earnings, fmt.Sprintf("%d unassigned", len(entry.Unassigned)))
+		}
+		if entry.Planned {
+			earnings = append(earnings, "planned")
+		}
+
+		fmt.Printf("%-4d  %-16s  %-11s  %-20d  %-8s  %s\n",
+			entry.ID,
+			entry.Timestamp.Local().Format("2006-01-02 15:04"),
+			entry.Strategy,
+			entry.Seed,
+			shortHash(entry.ConfigHash),
+			strings.Join(earnings, ", "))
+	}
+}
+
+func showHistory(id int) {
+	store := loadHistoryStore()
+	entry, err := store.Get(id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Distribution #%d from %s\n", entry.ID, entry.Timestamp.Local().Format("Monday, January 2, 2006 at 3:04 PM"))
+	fmt.Printf("Config: %s\n", shortHash(entry.ConfigHash))
+	result := entry.Result()
+	result.Fairness = distributor.ComputeFairness(result.People)
+	distributor.PrintDistribution(os.Stdout, result, distributor.PrintOptions{Verbose: verbose})
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/distributor"
+	"github.com/faradayfan/chore-distributor/internal/history"
+	"github.com/faradayfan/chore-distributor/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// runPlan distributes the next planWeeks weeks and prints them as a plan. Each week
+// is balanced against the weeks planned before it: earnings carry over and chores
+// rotate across the whole plan.
+func runPlan(cmd *cobra.Command, cfg *models.Config, strategy distributor.Strategy, runSeed uint64) {
+	if mode != "auto" {
+		fmt.Fprintf(os.Stderr, "Error: --weeks can't be used with --mode %s\n", mode)
+		os.Exit(1)
+	}
+	if sendSMS || noteName != "" || explain || len(pinArgs) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: --weeks can't be used with --sms, --note, --explain or --pin\n")
+		os.Exit(1)
+	}
+
+	saved := loadHistory(cfg)
+	var weeks []distributor.PlannedWeek
+	for {
+		// Each week counts the weeks planned before it as history
+		weeks = distributor.Plan(strategy, time.Now(), planWeeks, runSeed, func(weekStart time.Time, planned []distributor.PlannedWeek) distributor.Week {
+			entries := slices.Clone(saved)
+			for _, week := range planned {
+				entries = append(entries, history.NewEntry(week.Result, cfg.Hash, week.Start))
+			}
+			return prepareWeekOf(cmd, cfg, weekStart, entries, len(planned), true)
+		})
+
+		if verbose {
+			for _, week := range weeks {
+				fmt.Printf("\n--- Week of %s ---\n", week.Start.Format("Monday, January 2"))
+				distributor.PrintDistribution(os.Stdout, week.Result, distributor.PrintOptions{Verbose: true})
+			}
+		}
+		distributor.PrintPlan(os.Stdout, weeks)
+		fmt.Printf("Seed: %d\n", runSeed)
+
+		if confirm && record && !dryRun {
+			switch promptConfirmation() {
+			case "retry":
+				fmt.Println("--- Retrying plan ---")
+				runSeed = distributor.NewSeed()
+				continue
+			case "cancel":
+				fmt.Println("Cancelled.")
+				os.Exit(0)
+			case "confirm":
+			}
+		}
+
+		break
+	}
+
+	unassigned := 0
+	for _, week := range weeks {
+		unassigned += len(week.Result.Unassigned)
+	}
+	if unassigned > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %d chore(s) could not be assigned over the plan\n", unassigned)
+		if strict {
+			fmt.Fprintf(os.Stderr, "Error: not saving an incomplete plan (--strict)\n")
+			os.Exit(1)
+		}
+	}
+
+	if !dryRun && record {
+		store := history.NewStore(historyPath(cfg))
+		var first, last history.Entry
+		for i, week := range weeks {
+			// Planned weeks haven't happened yet, so later runs don't look back over them
+			entry := history.NewEntry(week.Result, cfg.Hash, week.Start)
+			entry.Planned = true
+			saved, err := store.Append(entry)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error saving to history: %v\n", err)
+				os.Exit(1)
+			}
+			if i == 0 {
+				first = saved
+			}
+			last = saved
+		}
+		fmt.Printf("\n✓ Saved as distributions #%d to #%d in %s\n", first.ID, last.ID, store.Path)
+	}
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/faradayfan/chore-distributor/internal/config"
+	"github.com/faradayfan/chore-distributor/internal/distributor"
+	"github.com/spf13/cobra"
+)
+
+var (
+	simulateRuns int
+	simulateJSON bool
+)
+
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Run the distribution many times to see how fair it is",
+	Long: `Distribute this week's chores many times over with different seeds, without
+sending, saving or recording anything, and report how the results vary:
+the spread of each person's earnings and difficulty, how often each person
+gets each chore, and how often chores go unassigned.
+
+A single distribution is shuffled at random, so it says little about
+whether a config is fair on its own; a simulation shows what to expect
+week to week. Runs are spread across every CPU, and the same --seed gives
+the same simulation.`,
+	Example: `  # Simulate 10,000 weeks with the configured strategy
+  chore-distributor simulate --runs 10000
+
+  # Compare strategies
+  chore-distributor simulate --strategy round-robin
+  chore-distributor simulate --strategy preference
+
+  # Write the results as JSON
+  chore-distributor simulate --json > simulation.json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runSimulate(cmd)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(simulateCmd)
+
+	simulateCmd.Flags().StringVarP(&configPath, "config", "c", "chores_config.json",
+		"Path to the JSON configuration file")
+	simulateCmd.Flags().IntVar(&simulateRuns, "runs", 1000,
+		"Number of distributions to run")
+	simulateCmd.Flags().StringVar(&strategyName, "strategy", "",
+		"Distribution strategy to simulate (overrides config file)")
+	simulateCmd.Flags().Uint64Var(&seed, "seed", 0,
+		"Seed for the random number generator, any number but 0 (default: random)")
+	simulateCmd.Flags().BoolVar(&simulateJSON, "json", false,
+		"Write the results as JSON instead of tables")
+}
+
+func runSimulate(cmd *cobra.Command) {
+	if simulateRuns < 1 {
+		fmt.Fprintf(os.Stderr, "Error: --runs must be at least 1, got %d\n", simulateRuns)
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	runSeed := selectSeed(cmd)
+	strategy := selectStrategy(cfg)
+	thisWeek := prepareWeek(cmd, cfg, true)
+
+	sim := distributor.Simulate(strategy, thisWeek.Chores, thisWeek.Present, thisWeek.Opts, simulateRuns, runSeed)
+	if simulateJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(sim); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	distributor.PrintSimulation(os.Stdout, sim)
+}
@@ -0,0 +1,180 @@
+// Package availability works out which days of a week people are away and scales
+// their capacity to match.
+package availability
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+	"github.com/faradayfan/chore-distributor/internal/recurrence"
+)
+
+// dateLayout is the format of the dates in models.Availability.
+const dateLayout = "2006-01-02"
+
+// Validate checks a person's availability rules.
+func Validate(person models.Person) error {
+	a := person.Availability
+	if a == nil {
+		return nil
+	}
+	for _, absence := range a.Away {
+		from, err := time.Parse(dateLayout, absence.From)
+		if err != nil {
+			return fmt.Errorf("person %q has an invalid away date %q (want YYYY-MM-DD)", person.Name, absence.From)
+		}
+		to, err := time.Parse(dateLayout, absence.To)
+		if err != nil {
+			return fmt.Errorf("person %q has an invalid away date %q (want YYYY-MM-DD)", person.Name, absence.To)
+		}
+		if to.Before(from) {
+			return fmt.Errorf("person %q is away from %s to %s, which ends before it starts", person.Name, absence.From, absence.To)
+		}
+	}
+	for _, day := range a.UnavailableDays {
+		if _, err := recurrence.ParseWeekday(day); err != nil {
+			return fmt.Errorf("person %q: %w", person.Name, err)
+		}
+	}
+	if alt := a.Alternating; alt != nil {
+		if _, err := time.Parse(dateLayout, alt.Start); err != nil {
+			return fmt.Errorf("person %q has an invalid alternating start %q (want YYYY-MM-DD)", person.Name, alt.Start)
+		}
+		if alt.WeeksOn < 0 || alt.WeeksOff < 0 {
+			return fmt.Errorf("person %q has a negative number of alternating weeks", person.Name)
+		}
+	}
+	return nil
+}
+
+// DaysOff returns the days of the week starting at start that the person is
+// unavailable, in date order. A day covered by more than one rule gets the reason of
+// the first: an absence, then the alternating pattern, then the unavailable days.
+func DaysOff(person models.Person, start time.Time) []models.DayOff {
+	a := person.Availability
+	if a == nil {
+		return nil
+	}
+
+	var off []models.DayOff
+	for d := 0; d < 7; d++ {
+		day := time.Date(start.Year(), start.Month(), start.Day()+d, 0, 0, 0, 0, start.Location())
+		if reason := reasonOff(a, day); reason != "" {
+			off = append(off, models.DayOff{Date: day, Reason: reason})
+		}
+	}
+	return off
+}
+
+// reasonOff returns why the person is unavailable on day, or "" if they are around.
+func reasonOff(a *models.Availability, day time.Time) string {
+	date := day.Format(dateLayout)
+	for _, absence := range a.Away {
+		// Dates in this layout sort the same as strings
+		if date >= absence.From && date <= absence.To {
+			return orDefault(absence.Reason, "away")
+		}
+	}
+	if alt := a.Alternating; alt != nil && !aroundOn(*alt, day) {
+		return orDefault(alt.Reason, "alternate week")
+	}
+	for _, name := range a.UnavailableDays {
+		if wd, err := recurrence.ParseWeekday(name); err == nil && wd == day.Weekday() {
+			return fmt.Sprintf("unavailable on %ss", wd)
+		}
+	}
+	return ""
+}
+
+// Apply returns a copy of the person adjusted for the week starting at start. Their
+// days off get no scheduled chores, and their EffortCapacity is scaled down to the
+// share of the week they are around (but never below 1, which would mean no limit).
+// If they are unavailable all week, Away says why.
+func Apply(person models.Person, start time.Time) models.Person {
+	off := DaysOff(person, start)
+	if len(off) == 0 {
+		return person
+	}
+
+	person.DaysOff = off
+	if len(off) == 7 {
+		var reasons []string
+		for _, day := range off {
+			if !slices.Contains(reasons, day.Reason) {
+				reasons = append(reasons, day.Reason)
+			}
+		}
+		person.Away = strings.Join(reasons, ", ")
+		return person
+	}
+
+	for _, day := range off {
+		person.DayCapacity = WithDayCapacity(person.DayCapacity, day.Date.Weekday(), 0)
+	}
+
+	if person.EffortCapacity > 0 {
+		scaled := math.Round(float64(person.EffortCapacity) * float64(7-len(off)) / 7)
+		person.EffortCapacity = max(int(scaled), 1)
+	}
+	return person
+}
+
+// WithDayCapacity returns a copy of a DayCapacity map with the limit for day set,
+// replacing any entry for the same day under another name ("Wed", "wednesday").
+func WithDayCapacity(capacity map[string]int, day time.Weekday, limit int) map[string]int {
+	updated := make(map[string]int, len(capacity)+1)
+	for name, l := range capacity {
+		if wd, err := recurrence.ParseWeekday(name); err != nil || wd != day {
+			updated[name] = l
+		}
+	}
+	updated[day.String()[:3]] = limit
+	return updated
+}
+
+// ForWeek applies each person's availability for the week starting at start, and
+// splits them into the people around for at least part of it and those away all week.
+func ForWeek(people []models.Person, start time.Time) (present, away []models.Person) {
+	for _, person := range people {
+		person = Apply(person, start)
+		if person.Away != "" {
+			away = append(away, person)
+		} else {
+			present = append(present, person)
+		}
+	}
+	return present, away
+}
+
+// aroundOn reports whether day falls in one of the pattern's weeks around.
+func aroundOn(alt models.Alternating, day time.Time) bool {
+	first, err := time.Parse(dateLayout, alt.Start)
+	if err != nil {
+		return true
+	}
+	on, off := max(alt.WeeksOn, 1), max(alt.WeeksOff, 1)
+
+	// Count whole days between the dates, ignoring time zones and daylight saving
+	date := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(date.Sub(first).Hours() / 24)
+	week := days / 7
+	if days < 0 && days%7 != 0 {
+		week--
+	}
+	phase := week % (on + off)
+	if phase < 0 {
+		phase += on + off
+	}
+	return phase < on
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,149 @@
+package availability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// monday is the start of the week used throughout these tests.
+var monday = time.Date(2025, time.June, 2, 9, 30, 0, 0, time.UTC)
+
+func TestDaysOff(t *testing.T) {
+	person := models.Person{
+		Name: "Alice",
+		Availability: &models.Availability{
+			Away:            []models.Absence{{From: "2025-06-05", To: "2025-06-06", Reason: "camp"}},
+			UnavailableDays: []string{"Wed", "thursday"},
+		},
+	}
+
+	off := DaysOff(person, monday)
+	want := []struct {
+		day    time.Weekday
+		reason string
+	}{
+		{time.Wednesday, "unavailable on Wednesdays"},
+		{time.Thursday, "camp"},
+		{time.Friday, "camp"},
+	}
+	if len(off) != len(want) {
+		t.Fatalf("Expected %d days off, got %+v", len(want), off)
+	}
+	for i, w := range want {
+		if off[i].Date.Weekday() != w.day || off[i].Reason != w.reason {
+			t.Errorf("Day off %d = %s %q, want %s %q", i, off[i].Date.Weekday(), off[i].Reason, w.day, w.reason)
+		}
+	}
+
+	if off := DaysOff(models.Person{Name: "Bob"}, monday); off != nil {
+		t.Errorf("Expected no days off without availability rules, got %+v", off)
+	}
+}
+
+func TestDaysOff_Alternating(t *testing.T) {
+	// Around from Friday May 30 for a week, then away from Friday June 6 for a week
+	person := models.Person{
+		Name:         "Alice",
+		Availability: &models.Availability{Alternating: &models.Alternating{Start: "2025-05-30", Reason: "at Dad's"}},
+	}
+
+	off := DaysOff(person, monday)
+	if len(off) != 3 || off[0].Date.Weekday() != time.Friday || off[0].Reason != "at Dad's" {
+		t.Errorf("Expected Friday to Sunday off, got %+v", off)
+	}
+
+	// Two weeks on, one off: the week of June 2 is the second week around
+	person.Availability.Alternating = &models.Alternating{Start: "2025-05-26", WeeksOn: 2, WeeksOff: 1}
+	if off := DaysOff(person, monday); len(off) != 0 {
+		t.Errorf("Expected no days off in the second week around, got %+v", off)
+	}
+	if off := DaysOff(person, monday.AddDate(0, 0, 7)); len(off) != 7 || off[0].Reason != "alternate week" {
+		t.Errorf("Expected the third week off, got %+v", off)
+	}
+
+	// Weeks before Start follow the same pattern
+	if off := DaysOff(person, monday.AddDate(0, 0, -14)); len(off) != 7 {
+		t.Errorf("Expected the week before Start off, got %+v", off)
+	}
+}
+
+func TestApply_PartWeek(t *testing.T) {
+	person := models.Person{
+		Name:           "Alice",
+		EffortCapacity: 14,
+		DayCapacity:    map[string]int{"Wednesday": 6, "Sat": 8},
+		Availability:   &models.Availability{UnavailableDays: []string{"Wed", "Thu"}},
+	}
+
+	got := Apply(person, monday)
+	if got.Away != "" {
+		t.Errorf("Expected Alice to be around, got away %q", got.Away)
+	}
+	if got.EffortCapacity != 10 {
+		t.Errorf("Expected capacity scaled to 10 for 5 of 7 days, got %d", got.EffortCapacity)
+	}
+	if len(got.DayCapacity) != 3 || got.DayCapacity["Wed"] != 0 || got.DayCapacity["Thu"] != 0 || got.DayCapacity["Sat"] != 8 {
+		t.Errorf("Expected Wed and Thu zeroed and Sat kept, got %v", got.DayCapacity)
+	}
+	if person.DayCapacity["Wednesday"] != 6 || person.EffortCapacity != 14 {
+		t.Error("Apply modified the original person")
+	}
+
+	// A small capacity never rounds down to 0, which would mean no limit
+	person.EffortCapacity = 1
+	person.Availability.UnavailableDays = []string{"Mon", "Tue", "Wed", "Thu", "Fri"}
+	if got := Apply(person, monday); got.EffortCapacity != 1 {
+		t.Errorf("Expected capacity of at least 1, got %d", got.EffortCapacity)
+	}
+}
+
+func TestForWeek(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Availability: &models.Availability{Away: []models.Absence{{From: "2025-06-01", To: "2025-06-05", Reason: "camp"}}}},
+		{Name: "Bob"},
+		{Name: "Carol", Availability: &models.Availability{
+			Away:            []models.Absence{{From: "2025-06-02", To: "2025-06-06", Reason: "sick"}},
+			UnavailableDays: []string{"Sat", "Sun"},
+		}},
+	}
+
+	present, away := ForWeek(people, monday)
+	if len(present) != 2 || present[0].Name != "Alice" || present[1].Name != "Bob" {
+		t.Errorf("Expected Alice and Bob around, got %+v", present)
+	}
+	if len(away) != 1 || away[0].Name != "Carol" {
+		t.Fatalf("Expected Carol away, got %+v", away)
+	}
+	if want := "sick, unavailable on Saturdays, unavailable on Sundays"; away[0].Away != want {
+		t.Errorf("Expected away reason %q, got %q", want, away[0].Away)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		avail   *models.Availability
+		wantErr bool
+	}{
+		{name: "none"},
+		{name: "valid", avail: &models.Availability{
+			Away:            []models.Absence{{From: "2025-06-01", To: "2025-06-01"}},
+			UnavailableDays: []string{"Tue"},
+			Alternating:     &models.Alternating{Start: "2025-01-03", WeeksOn: 2},
+		}},
+		{name: "bad from", avail: &models.Availability{Away: []models.Absence{{From: "06/01/2025", To: "2025-06-02"}}}, wantErr: true},
+		{name: "backwards", avail: &models.Availability{Away: []models.Absence{{From: "2025-06-03", To: "2025-06-02"}}}, wantErr: true},
+		{name: "bad day", avail: &models.Availability{UnavailableDays: []string{"Noday"}}, wantErr: true},
+		{name: "bad start", avail: &models.Availability{Alternating: &models.Alternating{Start: "soon"}}, wantErr: true},
+		{name: "negative weeks", avail: &models.Availability{Alternating: &models.Alternating{Start: "2025-01-03", WeeksOff: -1}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := Validate(models.Person{Name: "Alice", Availability: tt.avail})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package calendar
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/availability"
+	"github.com/faradayfan/chore-distributor/internal/distributor"
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// Hours is the part of each day chores get done in, as offsets from midnight.
+type Hours struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// DefaultHours is used when the config does not set calendarHours.
+var DefaultHours = Hours{Start: 7 * time.Hour, End: 21 * time.Hour}
+
+// ParseHours reads the configured calendar hours, or returns DefaultHours if h is nil.
+func ParseHours(h *models.CalendarHours) (Hours, error) {
+	if h == nil {
+		return DefaultHours, nil
+	}
+	start, err := parseClock(h.Start)
+	if err != nil {
+		return Hours{}, err
+	}
+	end, err := parseClock(h.End)
+	if err != nil {
+		return Hours{}, err
+	}
+	if end <= start {
+		return Hours{}, fmt.Errorf("calendar hours end at %s, which is not after they start at %s", h.End, h.Start)
+	}
+	return Hours{Start: start, End: end}, nil
+}
+
+// parseClock reads a time of day such as "07:00" or "24:00".
+func parseClock(s string) (time.Duration, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(s, "%d:%d", &hour, &minute); err != nil ||
+		hour < 0 || minute < 0 || minute > 59 || hour*60+minute > 24*60 {
+		return 0, fmt.Errorf("invalid time of day %q (want HH:MM)", s)
+	}
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute, nil
+}
+
+// maxRepeats bounds how many repeats of an event are looked at, so a daily event that
+// started long ago can't run forever.
+const maxRepeats = 100000
+
+// span is one occurrence of an event.
+type span struct {
+	start, end time.Time
+	summary    string
+}
+
+// occurrences returns the occurrences of the event that overlap from to to.
+func (e Event) occurrences(from, to time.Time) []span {
+	var spans []span
+	add := func(start time.Time) {
+		end := start.Add(e.End.Sub(e.Start))
+		if e.AllDay {
+			end = start.AddDate(0, 0, int(math.Round(e.End.Sub(e.Start).Hours()/24)))
+		}
+		if start.Before(to) && end.After(from) && !slices.ContainsFunc(e.Except, start.Equal) {
+			spans = append(spans, span{start: start, end: end, summary: e.Summary})
+		}
+	}
+
+	r := e.Rule
+	if r == nil {
+		add(e.Start)
+		return spans
+	}
+
+	byDay := r.ByDay
+	if len(byDay) == 0 {
+		byDay = []time.Weekday{e.Start.Weekday()}
+	}
+	// Weeks run Monday to Sunday
+	fromMonday := func(wd time.Weekday) int { return (int(wd) + 6) % 7 }
+	byDay = slices.Clone(byDay)
+	sort.Slice(byDay, func(i, j int) bool { return fromMonday(byDay[i]) < fromMonday(byDay[j]) })
+
+	count := 0
+	for k := 0; k < maxRepeats; k++ {
+		var starts []time.Time
+		switch r.Freq {
+		case "DAILY":
+			starts = []time.Time{e.Start.AddDate(0, 0, k*r.Interval)}
+		case "WEEKLY":
+			monday := e.Start.AddDate(0, 0, 7*k*r.Interval-fromMonday(e.Start.Weekday()))
+			for _, wd := range byDay {
+				starts = append(starts, monday.AddDate(0, 0, fromMonday(wd)))
+			}
+		case "MONTHLY":
+			starts = []time.Time{e.Start.AddDate(0, k*r.Interval, 0)}
+		case "YEARLY":
+			starts = []time.Time{e.Start.AddDate(k*r.Interval, 0, 0)}
+		}
+
+		for _, start := range starts {
+			if start.Before(e.Start) {
+				continue
+			}
+			if !start.Before(to) ||
+				(!r.Until.IsZero() && start.After(r.Until)) ||
+				(r.Count > 0 && count >= r.Count) {
+				return spans
+			}
+			count++
+			add(start)
+		}
+	}
+	return spans
+}
+
+// BusyDays returns the days of the week starting at start that the events take up
+// time inside hours, in date order, with how long and which events.
+func BusyDays(events []Event, start time.Time, hours Hours) []models.BusyDay {
+	var busy []models.BusyDay
+	for d := 0; d < 7; d++ {
+		day := time.Date(start.Year(), start.Month(), start.Day()+d, 0, 0, 0, 0, start.Location())
+		from, to := day.Add(hours.Start), day.Add(hours.End)
+
+		var spans []span
+		for _, event := range events {
+			for _, s := range event.occurrences(from, to) {
+				s.start, s.end = maxTime(s.start, from), minTime(s.end, to)
+				spans = append(spans, s)
+			}
+		}
+		if len(spans) == 0 {
+			continue
+		}
+		sort.Slice(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+
+		// Add up the time covered, counting overlapping events once
+		b := models.BusyDay{Date: day}
+		var covered time.Time
+		for _, s := range spans {
+			if s.start.After(covered) {
+				covered = s.start
+			}
+			if s.end.After(covered) {
+				b.Busy += s.end.Sub(covered)
+				covered = s.end
+			}
+			summary := s.summary
+			if summary == "" {
+				summary = "busy"
+			}
+			if !slices.Contains(b.Events, summary) {
+				b.Events = append(b.Events, summary)
+			}
+		}
+		busy = append(busy, b)
+	}
+	return busy
+}
+
+// Apply returns a copy of the person with their capacity reduced by the time events
+// have them busy in the week starting at start, on days they are not already off.
+// A day limit (DailyCapacity or DayCapacity) is cut by the share of that day's hours
+// they are busy, and a day they are busy all of gets no scheduled chores. Their
+// EffortCapacity is cut by their share of busy time across the days they are around,
+// but never below 1, which would mean no limit.
+func Apply(person models.Person, events []Event, start time.Time, hours Hours) models.Person {
+	off := make(map[string]bool)
+	for _, day := range person.DaysOff {
+		off[day.Date.Format(time.DateOnly)] = true
+	}
+
+	window := float64(hours.End - hours.Start)
+	var share float64
+	for _, day := range BusyDays(events, start, hours) {
+		if off[day.Date.Format(time.DateOnly)] {
+			continue
+		}
+
+		fraction := min(float64(day.Busy)/window, 1)
+		limit, limited := distributor.DayCapacity(person, day.Date.Weekday())
+		switch {
+		case fraction >= 1:
+			day.Full = true
+			if limited {
+				day.Lost = limit
+			}
+			person.DayCapacity = availability.WithDayCapacity(person.DayCapacity, day.Date.Weekday(), 0)
+		case limited:
+			day.Lost = int(math.Round(float64(limit) * fraction))
+			if day.Lost > 0 {
+				person.DayCapacity = availability.WithDayCapacity(person.DayCapacity, day.Date.Weekday(), limit-day.Lost)
+			}
+		}
+		share += fraction
+		person.Busy = append(person.Busy, day)
+	}
+
+	if person.EffortCapacity > 0 && share > 0 {
+		around := 7 - len(person.DaysOff)
+		lost := int(math.Round(float64(person.EffortCapacity) * share / float64(around)))
+		remaining := max(person.EffortCapacity-lost, 1)
+		person.CalendarLost = person.EffortCapacity - remaining
+		person.EffortCapacity = remaining
+	}
+	return person
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
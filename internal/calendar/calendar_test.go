@@ -0,0 +1,142 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// monday is the start of the week used throughout these tests.
+var monday = time.Date(2025, time.June, 2, 9, 30, 0, 0, time.UTC)
+
+func at(day, hour, minute int) time.Time {
+	return time.Date(2025, time.June, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestOccurrences_Weekly(t *testing.T) {
+	event := Event{
+		Summary: "Soccer",
+		Start:   at(2, 16, 0),
+		End:     at(2, 18, 0),
+		Rule:    &Rule{Freq: "WEEKLY", Interval: 1, ByDay: []time.Weekday{time.Wednesday, time.Monday}},
+		Except:  []time.Time{at(9, 16, 0)},
+	}
+
+	spans := event.occurrences(at(2, 0, 0), at(16, 0, 0))
+	var days []int
+	for _, s := range spans {
+		days = append(days, s.start.Day())
+	}
+	// Mondays and Wednesdays for two weeks, except Monday the 9th
+	want := []int{2, 4, 11}
+	if len(days) != len(want) {
+		t.Fatalf("Expected occurrences on %v, got %v", want, days)
+	}
+	for i := range want {
+		if days[i] != want[i] {
+			t.Errorf("Expected occurrences on %v, got %v", want, days)
+			break
+		}
+	}
+}
+
+func TestOccurrences_CountAndUntil(t *testing.T) {
+	daily := Event{Start: at(1, 8, 0), End: at(1, 9, 0), Rule: &Rule{Freq: "DAILY", Interval: 2, Count: 3}}
+	if spans := daily.occurrences(at(1, 0, 0), at(30, 0, 0)); len(spans) != 3 || spans[2].start.Day() != 5 {
+		t.Errorf("Expected 3 occurrences every other day, got %+v", spans)
+	}
+
+	until := Event{Start: at(1, 8, 0), End: at(1, 9, 0), Rule: &Rule{Freq: "DAILY", Interval: 1, Until: at(3, 8, 0)}}
+	if spans := until.occurrences(at(1, 0, 0), at(30, 0, 0)); len(spans) != 3 {
+		t.Errorf("Expected occurrences up to and including UNTIL, got %d", len(spans))
+	}
+}
+
+func TestBusyDays(t *testing.T) {
+	events := []Event{
+		{Summary: "Soccer", Start: at(4, 16, 0), End: at(4, 18, 0)},
+		// Overlaps soccer by an hour, so only adds an hour
+		{Summary: "Tutor", Start: at(4, 17, 0), End: at(4, 19, 0)},
+		// Starts before the day's hours, so only 8:00 to 9:00 counts
+		{Summary: "Early", Start: at(5, 6, 0), End: at(5, 9, 0)},
+		{Summary: "Trip", Start: at(7, 0, 0), End: at(8, 0, 0), AllDay: true},
+	}
+
+	busy := BusyDays(events, monday, Hours{Start: 8 * time.Hour, End: 20 * time.Hour})
+	if len(busy) != 3 {
+		t.Fatalf("Expected 3 busy days, got %+v", busy)
+	}
+	if busy[0].Date.Weekday() != time.Wednesday || busy[0].Busy != 3*time.Hour || strings.Join(busy[0].Events, ",") != "Soccer,Tutor" {
+		t.Errorf("Expected 3h on Wednesday for Soccer and Tutor, got %+v", busy[0])
+	}
+	if busy[1].Busy != time.Hour {
+		t.Errorf("Expected 1h on Thursday, got %v", busy[1].Busy)
+	}
+	if busy[2].Date.Weekday() != time.Saturday || busy[2].Busy != 12*time.Hour {
+		t.Errorf("Expected all 12 hours on Saturday, got %+v", busy[2])
+	}
+}
+
+func TestApply(t *testing.T) {
+	person := models.Person{
+		Name:           "Alice",
+		EffortCapacity: 14,
+		DailyCapacity:  6,
+		DaysOff:        []models.DayOff{{Date: time.Date(2025, time.June, 6, 0, 0, 0, 0, time.UTC), Reason: "camp"}},
+	}
+	events := []Event{
+		{Summary: "Soccer", Start: at(4, 16, 0), End: at(4, 19, 30)},
+		{Summary: "Trip", Start: at(7, 0, 0), End: at(8, 0, 0), AllDay: true},
+		// Friday is already a day off, so this costs nothing more
+		{Summary: "Camp", Start: at(6, 8, 0), End: at(6, 20, 0)},
+	}
+
+	got := Apply(person, events, monday, DefaultHours)
+	if len(got.Busy) != 2 {
+		t.Fatalf("Expected 2 busy days, got %+v", got.Busy)
+	}
+
+	// 3.5 of 14 hours busy on Wednesday: a quarter of the day's 6
+	wed := got.Busy[0]
+	if wed.Lost != 2 || wed.Full {
+		t.Errorf("Expected Wednesday to lose 2, got %+v", wed)
+	}
+	if limit := got.DayCapacity["Wed"]; limit != 4 {
+		t.Errorf("Expected Wednesday capacity of 4, got %d", limit)
+	}
+
+	sat := got.Busy[1]
+	if !sat.Full || sat.Lost != 6 || got.DayCapacity["Sat"] != 0 {
+		t.Errorf("Expected Saturday to be a full day lost, got %+v, capacity %v", sat, got.DayCapacity)
+	}
+
+	// 1.25 days busy out of the 6 around: 14 × 1.25 / 6 ≈ 3
+	if got.CalendarLost != 3 || got.EffortCapacity != 11 {
+		t.Errorf("Expected effort capacity 14 - 3 = 11, got %d (lost %d)", got.EffortCapacity, got.CalendarLost)
+	}
+	if person.EffortCapacity != 14 || person.DayCapacity != nil {
+		t.Error("Apply modified the original person")
+	}
+}
+
+func TestParseHours(t *testing.T) {
+	hours, err := ParseHours(&models.CalendarHours{Start: "15:30", End: "24:00"})
+	if err != nil || hours.Start != 15*time.Hour+30*time.Minute || hours.End != 24*time.Hour {
+		t.Errorf("ParseHours = %+v, %v", hours, err)
+	}
+	if hours, err := ParseHours(nil); err != nil || hours != DefaultHours {
+		t.Errorf("Expected the default hours, got %+v, %v", hours, err)
+	}
+
+	for _, bad := range []models.CalendarHours{
+		{Start: "9", End: "17:00"},
+		{Start: "09:00", End: "25:00"},
+		{Start: "17:00", End: "09:00"},
+	} {
+		if _, err := ParseHours(&bad); err == nil {
+			t.Errorf("ParseHours(%+v): expected an error", bad)
+		}
+	}
+}
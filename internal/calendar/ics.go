@@ -0,0 +1,310 @@
+// Package calendar reads busy time from local iCalendar (.ics) files and turns it into
+// reduced capacity for the week being distributed.
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is a busy block from a calendar, possibly repeating.
+type Event struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	// End is when the first occurrence ends. For all-day events Start and End are
+	// midnight on the first day and the day after the last.
+	End    time.Time
+	AllDay bool
+	Rule   *Rule
+	// Except are the starts of occurrences that were cancelled or moved.
+	Except []time.Time
+}
+
+// Rule is the subset of an RRULE this package understands: DAILY, WEEKLY (with BYDAY),
+// MONTHLY and YEARLY repeats with INTERVAL, COUNT and UNTIL.
+type Rule struct {
+	Freq     string
+	Interval int
+	Count    int
+	Until    time.Time
+	ByDay    []time.Weekday
+}
+
+// Load reads the events in an .ics file. Times without a time zone are read in loc.
+func Load(path string, loc *time.Location) ([]Event, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening calendar: %w", err)
+	}
+	defer file.Close()
+
+	events, err := Parse(file, loc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return events, nil
+}
+
+// Parse reads the busy events in iCalendar data. Cancelled events and events marked as
+// free (TRANSP:TRANSPARENT) are left out. Times without a time zone are read in loc.
+func Parse(r io.Reader, loc *time.Location) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events   []Event
+		event    *Event
+		skip     bool
+		duration time.Duration
+		moved    = make(map[string][]time.Time) // RECURRENCE-ID of moved occurrences, by UID
+		depth    int                            // components nested inside the VEVENT, such as VALARM
+	)
+	for n, line := range lines {
+		name, params, value, ok := splitLine(line)
+		if !ok {
+			return nil, fmt.Errorf("line %d: invalid content line %q", n+1, line)
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT") && event == nil:
+			event, skip, duration, depth = &Event{}, false, 0, 0
+			continue
+		case event == nil:
+			continue
+		case name == "BEGIN":
+			depth++
+			continue
+		case name == "END" && depth > 0:
+			depth--
+			continue
+		case depth > 0:
+			continue
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if event.Start.IsZero() {
+				return nil, fmt.Errorf("line %d: event %q has no DTSTART", n+1, event.Summary)
+			}
+			if event.End.IsZero() {
+				event.End = event.Start.Add(duration)
+				if event.AllDay && duration == 0 {
+					event.End = event.Start.AddDate(0, 0, 1)
+				}
+			}
+			if !skip {
+				events = append(events, *event)
+			}
+			event = nil
+			continue
+		}
+
+		switch name {
+		case "UID":
+			event.UID = value
+		case "SUMMARY":
+			event.Summary = unescape(value)
+		case "DTSTART":
+			event.Start, event.AllDay, err = parseTime(value, params, loc)
+		case "DTEND":
+			event.End, _, err = parseTime(value, params, loc)
+		case "DURATION":
+			duration, err = parseDuration(value)
+		case "RRULE":
+			event.Rule, err = parseRule(value, loc)
+		case "EXDATE":
+			for _, v := range strings.Split(value, ",") {
+				var t time.Time
+				if t, _, err = parseTime(v, params, loc); err != nil {
+					break
+				}
+				event.Except = append(event.Except, t)
+			}
+		case "RECURRENCE-ID":
+			var t time.Time
+			if t, _, err = parseTime(value, params, loc); err == nil {
+				moved[event.UID] = append(moved[event.UID], t)
+				// A moved occurrence is an event of its own, not another repeat
+				event.Rule = nil
+			}
+		case "TRANSP":
+			skip = skip || strings.EqualFold(value, "TRANSPARENT")
+		case "STATUS":
+			skip = skip || strings.EqualFold(value, "CANCELLED")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", n+1, name, err)
+		}
+	}
+
+	// The original occurrence of a moved one no longer happens
+	for i := range events {
+		if events[i].Rule != nil {
+			events[i].Except = append(events[i].Except, moved[events[i].UID]...)
+		}
+	}
+	return events, nil
+}
+
+// unfold reads content lines, joining folded lines (those starting with a space or tab)
+// onto the line before.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// splitLine splits a content line such as DTSTART;TZID=Europe/Paris:20250604T160000
+// into its upper-cased name, parameters and value.
+func splitLine(line string) (name string, params map[string]string, value string, ok bool) {
+	quoted := false
+	colon := -1
+	for i, c := range line {
+		if c == '"' {
+			quoted = !quoted
+		} else if c == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params = make(map[string]string)
+	for _, p := range parts[1:] {
+		if k, v, found := strings.Cut(p, "="); found {
+			params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:], true
+}
+
+// parseTime reads a DATE or DATE-TIME value, in UTC if it ends in Z, otherwise in its
+// TZID time zone or loc.
+func parseTime(value string, params map[string]string, loc *time.Location) (t time.Time, allDay bool, err error) {
+	if tzid := params["TZID"]; tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			loc = tz
+		}
+	}
+	value = strings.TrimSpace(value)
+	switch {
+	case params["VALUE"] == "DATE" || len(value) == 8:
+		t, err = time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	case strings.HasSuffix(value, "Z"):
+		t, err = time.Parse("20060102T150405Z", value)
+		return t, false, err
+	default:
+		t, err = time.ParseInLocation("20060102T150405", value, loc)
+		return t, false, err
+	}
+}
+
+// parseDuration reads a DURATION value such as PT1H30M, P1D or P2W.
+func parseDuration(value string) (time.Duration, error) {
+	s := strings.TrimPrefix(strings.TrimPrefix(value, "+"), "P")
+	if s == value || strings.HasPrefix(value, "-") {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	units := map[byte]time.Duration{
+		'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour,
+		'H': time.Hour, 'M': time.Minute, 'S': time.Second,
+	}
+	var total time.Duration
+	num := ""
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			num += string(c)
+		case c == 'T':
+		case units[c] != 0 && num != "":
+			n, _ := strconv.Atoi(num)
+			total += time.Duration(n) * units[c]
+			num = ""
+		default:
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+	}
+	if num != "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return total, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// parseRule reads an RRULE value such as FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20250630.
+func parseRule(value string, loc *time.Location) (*Rule, error) {
+	rule := &Rule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		k, v, _ := strings.Cut(part, "=")
+		var err error
+		switch strings.ToUpper(k) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(v)
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(v)
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(v)
+		case "UNTIL":
+			rule.Until, _, err = parseTime(v, nil, loc)
+		case "BYDAY":
+			for _, day := range strings.Split(v, ",") {
+				// Ordinals such as 1MO only make sense monthly; use the weekday
+				if len(day) < 2 {
+					return nil, fmt.Errorf("invalid BYDAY %q", v)
+				}
+				wd, ok := weekdays[strings.ToUpper(day[len(day)-2:])]
+				if !ok {
+					return nil, fmt.Errorf("invalid BYDAY %q", v)
+				}
+				if !slices.Contains(rule.ByDay, wd) {
+					rule.ByDay = append(rule.ByDay, wd)
+				}
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", k, v)
+		}
+	}
+
+	switch rule.Freq {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+	default:
+		return nil, fmt.Errorf("unsupported frequency %q", rule.Freq)
+	}
+	if rule.Interval < 1 {
+		return nil, fmt.Errorf("invalid INTERVAL %d", rule.Interval)
+	}
+	return rule, nil
+}
+
+// unescape undoes iCalendar TEXT escaping.
+func unescape(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, `;`, `\,`, `,`, `\n`, " ", `\N`, " ").Replace(s)
+}
@@ -0,0 +1,152 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleICS = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Test//EN
+BEGIN:VEVENT
+UID:soccer@example.com
+SUMMARY:Soccer\, U12
+DTSTART;TZID=America/New_York:20250507T160000
+DTEND;TZID=America/New_York:20250507T180000
+RRULE:FREQ=WEEKLY;BYDAY=WE
+EXDATE;TZID=America/New_York:20250521T160000
+BEGIN:VALARM
+ACTION:DISPLAY
+TRIGGER:-PT30M
+END:VALARM
+END:VEVENT
+BEGIN:VEVENT
+UID:dentist@example.com
+SUMMARY:Dentist with a long description that gets folded over
+  two lines
+DTSTART:20250603T140000Z
+DURATION:PT1H30M
+END:VEVENT
+BEGIN:VEVENT
+UID:trip@example.com
+SUMMARY:Trip
+DTSTART;VALUE=DATE:20250607
+DTEND;VALUE=DATE:20250609
+END:VEVENT
+BEGIN:VEVENT
+UID:birthday@example.com
+SUMMARY:Grandma's birthday
+DTSTART;VALUE=DATE:20250604
+TRANSP:TRANSPARENT
+END:VEVENT
+BEGIN:VEVENT
+UID:cancelled@example.com
+SUMMARY:Piano
+DTSTART:20250605T150000
+DTEND:20250605T160000
+STATUS:CANCELLED
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestParse(t *testing.T) {
+	events, err := Parse(strings.NewReader(strings.ReplaceAll(sampleICS, "\n", "\r\n")), time.UTC)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 busy events (free and cancelled ones skipped), got %d: %+v", len(events), events)
+	}
+
+	soccer := events[0]
+	if soccer.Summary != "Soccer, U12" {
+		t.Errorf("Expected an unescaped summary, got %q", soccer.Summary)
+	}
+	if soccer.Start.Location().String() != "America/New_York" || soccer.Start.Hour() != 16 {
+		t.Errorf("Expected 16:00 New York time, got %v", soccer.Start)
+	}
+	if soccer.Rule == nil || soccer.Rule.Freq != "WEEKLY" || len(soccer.Rule.ByDay) != 1 || soccer.Rule.ByDay[0] != time.Wednesday {
+		t.Errorf("Expected a weekly rule on Wednesdays, got %+v", soccer.Rule)
+	}
+	if len(soccer.Except) != 1 || soccer.Except[0].Day() != 21 {
+		t.Errorf("Expected one excluded date, got %v", soccer.Except)
+	}
+
+	dentist := events[1]
+	if !strings.HasSuffix(dentist.Summary, "folded over two lines") {
+		t.Errorf("Expected folded lines to be joined, got %q", dentist.Summary)
+	}
+	if got := dentist.End.Sub(dentist.Start); got != 90*time.Minute {
+		t.Errorf("Expected a 90 minute duration, got %v", got)
+	}
+
+	trip := events[2]
+	if !trip.AllDay || trip.End.Sub(trip.Start) != 48*time.Hour {
+		t.Errorf("Expected a two day all-day event, got %+v", trip)
+	}
+}
+
+func TestParse_MovedOccurrence(t *testing.T) {
+	ics := `BEGIN:VCALENDAR
+BEGIN:VEVENT
+UID:piano
+SUMMARY:Piano
+DTSTART:20250602T170000
+DTEND:20250602T180000
+RRULE:FREQ=DAILY;COUNT=5
+END:VEVENT
+BEGIN:VEVENT
+UID:piano
+RECURRENCE-ID:20250604T170000
+SUMMARY:Piano
+DTSTART:20250604T190000
+DTEND:20250604T200000
+END:VEVENT
+END:VCALENDAR
+`
+	events, err := Parse(strings.NewReader(ics), time.UTC)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(events) != 2 || events[1].Rule != nil {
+		t.Fatalf("Expected the moved occurrence as its own event, got %+v", events)
+	}
+	if len(events[0].Except) != 1 || !events[0].Except[0].Equal(time.Date(2025, time.June, 4, 17, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the original occurrence to be excluded, got %v", events[0].Except)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		ics  string
+	}{
+		{"no start", "BEGIN:VEVENT\nSUMMARY:Oops\nEND:VEVENT\n"},
+		{"bad start", "BEGIN:VEVENT\nDTSTART:tomorrow\nEND:VEVENT\n"},
+		{"bad duration", "BEGIN:VEVENT\nDTSTART:20250602T170000\nDURATION:1 hour\nEND:VEVENT\n"},
+		{"unsupported rule", "BEGIN:VEVENT\nDTSTART:20250602T170000\nRRULE:FREQ=HOURLY\nEND:VEVENT\n"},
+		{"bad content line", "BEGIN:VEVENT\nDTSTART 20250602\nEND:VEVENT\n"},
+	}
+
+	for _, tt := range tests {
+		if _, err := Parse(strings.NewReader(tt.ics), time.UTC); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"PT1H30M": 90 * time.Minute,
+		"P1D":     24 * time.Hour,
+		"P1W":     7 * 24 * time.Hour,
+		"PT45S":   45 * time.Second,
+		"P1DT2H":  26 * time.Hour,
+	}
+	for in, want := range tests {
+		if got, err := parseDuration(in); err != nil || got != want {
+			t.Errorf("parseDuration(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+}
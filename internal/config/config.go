@@ -1,11 +1,18 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"time"
 
+	"github.com/faradayfan/chore-distributor/internal/availability"
+	"github.com/faradayfan/chore-distributor/internal/calendar"
 	"github.com/faradayfan/chore-distributor/internal/models"
+	"github.com/faradayfan/chore-distributor/internal/recurrence"
 )
 
 func Load(filename string) (*models.Config, error) {
@@ -20,6 +27,44 @@ func Load(filename string) (*models.Config, error) {
 		return nil, fmt.Errorf("error parsing JSON: %w", err)
 	}
 
+	sum := sha256.Sum256(data)
+	config.Hash = hex.EncodeToString(sum[:])
+
+	if w := config.Weights; w != nil {
+		if w.Earnings < 0 || w.Difficulty < 0 || w.Chores < 0 {
+			return nil, fmt.Errorf("weights must not be negative")
+		}
+		if w.Earnings == 0 && w.Difficulty == 0 && w.Chores == 0 {
+			return nil, fmt.Errorf("at least one weight must be greater than zero")
+		}
+	}
+
+	if r := config.Rotation; r != nil && (r.Weeks < 0 || r.Penalty < 0) {
+		return nil, fmt.Errorf("rotation weeks and penalty must not be negative")
+	}
+	if c := config.CarryOver; c != nil && c.Weeks < 0 {
+		return nil, fmt.Errorf("carryOver weeks must not be negative")
+	}
+
+	if err := validateEligibility(&config); err != nil {
+		return nil, err
+	}
+	if err := validateTeams(&config); err != nil {
+		return nil, err
+	}
+	if err := validateSchedule(&config); err != nil {
+		return nil, err
+	}
+	if err := validateChoreNames(&config); err != nil {
+		return nil, err
+	}
+	if err := validatePreferences(&config); err != nil {
+		return nil, err
+	}
+	if err := validatePricing(&config); err != nil {
+		return nil, err
+	}
+
 	for i := range config.People {
 		if config.People[i].Chores == nil {
 			config.People[i].Chores = []models.Chore{}
@@ -34,3 +79,228 @@ func Load(filename string) (*models.Config, error) {
 
 	return &config, nil
 }
+
+// LoadBids reads an auction bids file: for each person, the least they would accept
+// for each chore, such as {"Alice": {"Dishes": 3, "Mow Lawn": 8}}. Names must match
+// people and chores in the config, and prices must not be negative.
+func LoadBids(filename string, config *models.Config) (map[string]map[string]int, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error reading bids: %w", err)
+	}
+
+	var bids map[string]map[string]int
+	if err := json.Unmarshal(data, &bids); err != nil {
+		return nil, fmt.Errorf("error parsing bids: %w", err)
+	}
+
+	for person, prices := range bids {
+		if !slices.ContainsFunc(config.People, func(p models.Person) bool { return p.Name == person }) {
+			return nil, fmt.Errorf("bids for unknown person %q", person)
+		}
+		for chore, price := range prices {
+			if !slices.ContainsFunc(config.Chores, func(c models.Chore) bool { return c.Name == chore }) {
+				return nil, fmt.Errorf("person %q bids on unknown chore %q", person, chore)
+			}
+			if price < 0 {
+				return nil, fmt.Errorf("person %q has a negative bid for %q", person, chore)
+			}
+		}
+	}
+	return bids, nil
+}
+
+// validateEligibility checks that birthdates parse and that chores only name people who
+// are in the config, so a typo can't silently keep a chore from everyone.
+func validateEligibility(config *models.Config) error {
+	names := make(map[string]bool)
+	for _, person := range config.People {
+		names[person.Name] = true
+		if person.Age < 0 {
+			return fmt.Errorf("person %q has a negative age", person.Name)
+		}
+		if person.Birthdate != "" {
+			if _, err := time.Parse("2006-01-02", person.Birthdate); err != nil {
+				return fmt.Errorf("person %q has an invalid birthdate %q (want YYYY-MM-DD)", person.Name, person.Birthdate)
+			}
+		}
+	}
+
+	for _, chore := range config.Chores {
+		if chore.MinAge < 0 {
+			return fmt.Errorf("chore %q has a negative minimum age", chore.Name)
+		}
+		for _, name := range chore.AllowedPeople {
+			if !names[name] {
+				return fmt.Errorf("chore %q allows unknown person %q", chore.Name, name)
+			}
+		}
+		for _, name := range chore.DeniedPeople {
+			if !names[name] {
+				return fmt.Errorf("chore %q denies unknown person %q", chore.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateTeams checks that team chores have a valid headcount and split, and that
+// neverPair groups name at least two people who are in the config.
+func validateTeams(config *models.Config) error {
+	for _, chore := range config.Chores {
+		if chore.Headcount < 0 {
+			return fmt.Errorf("chore %q has a negative headcount", chore.Name)
+		}
+		switch chore.Split {
+		case "", models.SplitEven, models.SplitFull:
+		default:
+			return fmt.Errorf("chore %q has an invalid split %q (want %q or %q)", chore.Name, chore.Split, models.SplitEven, models.SplitFull)
+		}
+	}
+
+	for _, group := range config.NeverPair {
+		if len(group) < 2 {
+			return fmt.Errorf("neverPair group %q must name at least two people", group)
+		}
+		for _, name := range group {
+			if !slices.ContainsFunc(config.People, func(p models.Person) bool { return p.Name == name }) {
+				return fmt.Errorf("neverPair names unknown person %q", name)
+			}
+		}
+	}
+	return nil
+}
+
+// validatePreferences checks that preferences rate chores that are in the config with a
+// known rating, and that the preference tolerance isn't negative.
+func validatePreferences(config *models.Config) error {
+	for _, person := range config.People {
+		for name, rating := range person.Preferences {
+			if !slices.ContainsFunc(config.Chores, func(c models.Chore) bool { return c.Name == name }) {
+				return fmt.Errorf("person %q rates unknown chore %q", person.Name, name)
+			}
+			switch rating {
+			case models.Love, models.OK, models.Hate:
+			default:
+				return fmt.Errorf("person %q rates %q as %q (want %q, %q or %q)",
+					person.Name, name, rating, models.Love, models.OK, models.Hate)
+			}
+		}
+	}
+	if t := config.PreferenceTolerance; t != nil && *t < 0 {
+		return fmt.Errorf("preferenceTolerance must not be negative, got %d", *t)
+	}
+	return nil
+}
+
+// validatePricing checks the settings that change what chores earn.
+func validatePricing(config *models.Config) error {
+	if config.AuctionBudget < 0 {
+		return fmt.Errorf("auctionBudget must not be negative, got %d", config.AuctionBudget)
+	}
+	if surge := config.Surge; surge != nil {
+		if surge.Max <= 0 {
+			return fmt.Errorf("surge max must be greater than zero, got %d", surge.Max)
+		}
+		if surge.Step < 0 {
+			return fmt.Errorf("surge step must not be negative, got %d", surge.Step)
+		}
+		if surge.Weeks < 0 {
+			return fmt.Errorf("surge weeks must not be negative, got %d", surge.Weeks)
+		}
+	}
+	return nil
+}
+
+// validateChoreNames checks that together and apart groups name at least two chores
+// that are in the config and don't contradict each other.
+func validateChoreNames(config *models.Config) error {
+	chores := make(map[string]models.Chore)
+	for _, chore := range config.Chores {
+		chores[chore.Name] = chore
+	}
+	check := func(key string, groups [][]string) error {
+		for _, group := range groups {
+			if len(group) < 2 {
+				return fmt.Errorf("%s group %q must name at least two chores", key, group)
+			}
+			for _, name := range group {
+				if _, ok := chores[name]; !ok {
+					return fmt.Errorf("%s names unknown chore %q", key, name)
+				}
+			}
+		}
+		return nil
+	}
+	if err := check("together", config.Together); err != nil {
+		return err
+	}
+	if err := check("apart", config.Apart); err != nil {
+		return err
+	}
+
+	group := make(map[string]int)
+	for g, names := range config.Together {
+		for _, name := range names {
+			if chores[name].Headcount > 1 {
+				return fmt.Errorf("chore %q takes a team, so it can't be kept together with other chores", name)
+			}
+			if other, ok := group[name]; ok && other != g {
+				return fmt.Errorf("chore %q is in more than one together group", name)
+			}
+			group[name] = g
+		}
+	}
+	for _, names := range config.Apart {
+		for a, name := range names {
+			for _, other := range names[a+1:] {
+				if g, ok := group[name]; ok && name != other && slices.Contains(config.Together[g], other) {
+					return fmt.Errorf("chores %q and %q are kept both together and apart", name, other)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateSchedule checks recurring chores, allowed days, daily capacities,
+// availability rules and calendar settings.
+func validateSchedule(config *models.Config) error {
+	for _, chore := range config.Chores {
+		if err := recurrence.Validate(chore); err != nil {
+			return err
+		}
+	}
+	for _, person := range config.People {
+		if person.DailyCapacity < 0 {
+			return fmt.Errorf("person %q has a negative daily capacity", person.Name)
+		}
+		// Each day can only be given once, or which limit applies would depend on map order
+		days := make(map[time.Weekday]string)
+		for day, capacity := range person.DayCapacity {
+			wd, err := recurrence.ParseWeekday(day)
+			if err != nil {
+				return fmt.Errorf("person %q: %w", person.Name, err)
+			}
+			if other, ok := days[wd]; ok {
+				names := []string{other, day}
+				slices.Sort(names)
+				return fmt.Errorf("person %q has a capacity for %s twice, as %q and %q", person.Name, wd, names[0], names[1])
+			}
+			days[wd] = day
+			if capacity < 0 {
+				return fmt.Errorf("person %q has a negative capacity on %s", person.Name, day)
+			}
+		}
+		if err := availability.Validate(person); err != nil {
+			return err
+		}
+		if slices.Contains(person.Calendars, "") {
+			return fmt.Errorf("person %q has an empty calendar path", person.Name)
+		}
+	}
+	if _, err := calendar.ParseHours(config.CalendarHours); err != nil {
+		return err
+	}
+	return nil
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"testing"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
 )
 
 func TestLoad_ValidFile(t *testing.T) {
@@ -55,6 +57,10 @@ func TestLoad_ValidFile(t *testing.T) {
 		t.Errorf("Expected Bob's capacity to be 15, got %d", config.People[1].EffortCapacity)
 	}
 
+	if len(config.Hash) != 64 {
+		t.Errorf("Expected a SHA-256 hex hash of the file, got %q", config.Hash)
+	}
+
 	for i, person := range config.People {
 		if person.Chores == nil {
 			t.Errorf("Person %d (%s) has nil Chores slice", i, person.Name)
@@ -212,3 +218,296 @@ func TestLoad_ZeroValuePreAssignedChores(t *testing.T) {
 		t.Errorf("Expected TotalEarned to be 0, got %d", config.People[0].TotalEarned)
 	}
 }
+
+func TestLoad_Weights(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights string
+		wantErr bool
+	}{
+		{name: "valid", weights: `{"earnings": 1, "difficulty": 0.5}`},
+		{name: "negative", weights: `{"earnings": -1, "difficulty": 1}`, wantErr: true},
+		{name: "all zero", weights: `{"earnings": 0, "difficulty": 0}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpfile, err := os.CreateTemp("", "test_weights_*.json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(tmpfile.Name())
+
+			content := `{"chores": [], "people": [], "weights": ` + tt.weights + `}`
+			if _, err := tmpfile.Write([]byte(content)); err != nil {
+				t.Fatal(err)
+			}
+			if err := tmpfile.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			config, err := Load(tmpfile.Name())
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected an error for invalid weights")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to load config: %v", err)
+			}
+			if config.Weights == nil || config.Weights.Difficulty != 0.5 {
+				t.Errorf("Expected difficulty weight 0.5, got %+v", config.Weights)
+			}
+		})
+	}
+}
+
+func TestLoad_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			content: `{"chores": [{"Name": "Mow", "MinAge": 12, "AllowedPeople": ["Alice"], "Skills": ["mowing"], "Frequency": "biweekly", "Days": ["Sat", "sunday"]}, {"Name": "Garage", "Headcount": 2, "Split": "full"}, {"Name": "Load"}, {"Name": "Unload"}],
+				"people": [{"Name": "Alice", "Birthdate": "2010-04-01", "Skills": ["mowing"], "Preferences": {"Mow": "love", "Load": "hate"}, "DailyCapacity": 5, "DayCapacity": {"Sat": 10}},
+					{"Name": "Bob", "Age": 9, "Availability": {"Away": [{"From": "2025-07-06", "To": "2025-07-19", "Reason": "camp"}], "UnavailableDays": ["Wed"], "Alternating": {"Start": "2025-01-03"}}, "Calendars": ["bob.ics"]}],
+				"calendarHours": {"start": "15:00", "end": "21:30"}, "neverPair": [["Alice", "Bob"]],
+				"together": [["Load", "Unload"]], "apart": [["Mow", "Load"]], "preferenceTolerance": 3,
+				"surge": {"weeks": 4, "step": 1, "max": 3}}`,
+		},
+		{
+			name:    "invalid frequency",
+			content: `{"chores": [{"Name": "Dishes", "Frequency": "twice daily"}], "people": [{"Name": "Alice"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid day",
+			content: `{"chores": [{"Name": "Trash", "Days": ["Tuesday", "Someday"]}], "people": [{"Name": "Alice"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "more times per week than allowed days",
+			content: `{"chores": [{"Name": "Trash", "Frequency": "3/week", "Days": ["Tue", "Fri"]}], "people": [{"Name": "Alice"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid day capacity",
+			content: `{"chores": [], "people": [{"Name": "Alice", "DayCapacity": {"Caturday": 4}}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid away date",
+			content: `{"chores": [], "people": [{"Name": "Alice", "Availability": {"Away": [{"From": "July 6", "To": "2025-07-19"}]}}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "away range ends before it starts",
+			content: `{"chores": [], "people": [{"Name": "Alice", "Availability": {"Away": [{"From": "2025-07-19", "To": "2025-07-06"}]}}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid unavailable day",
+			content: `{"chores": [], "people": [{"Name": "Alice", "Availability": {"UnavailableDays": ["Funday"]}}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid alternating start",
+			content: `{"chores": [], "people": [{"Name": "Alice", "Availability": {"Alternating": {"Start": ""}}}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid calendar hours",
+			content: `{"chores": [], "people": [{"Name": "Alice", "Calendars": ["alice.ics"]}], "calendarHours": {"start": "21:00", "end": "07:00"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "empty calendar path",
+			content: `{"chores": [], "people": [{"Name": "Alice", "Calendars": [""]}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid birthdate",
+			content: `{"chores": [], "people": [{"Name": "Alice", "Birthdate": "04/01/2010"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown allowed person",
+			content: `{"chores": [{"Name": "Mow", "AllowedPeople": ["Alcie"]}], "people": [{"Name": "Alice"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "negative headcount",
+			content: `{"chores": [{"Name": "Garage", "Headcount": -2}], "people": [{"Name": "Alice"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid split",
+			content: `{"chores": [{"Name": "Garage", "Headcount": 2, "Split": "halves"}], "people": [{"Name": "Alice"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "never pair one person",
+			content: `{"chores": [], "people": [{"Name": "Alice"}], "neverPair": [["Alice"]]}`,
+			wantErr: true,
+		},
+		{
+			name:    "never pair unknown person",
+			content: `{"chores": [], "people": [{"Name": "Alice"}], "neverPair": [["Alice", "Bobby"]]}`,
+			wantErr: true,
+		},
+		{
+			name:    "together group of one",
+			content: `{"chores": [{"Name": "Load"}], "people": [{"Name": "Alice"}], "together": [["Load"]]}`,
+			wantErr: true,
+		},
+		{
+			name:    "apart unknown chore",
+			content: `{"chores": [{"Name": "Kitchen"}], "people": [{"Name": "Alice"}], "apart": [["Kitchen", "Dinning Room"]]}`,
+			wantErr: true,
+		},
+		{
+			name:    "together and apart",
+			content: `{"chores": [{"Name": "Load"}, {"Name": "Unload"}], "people": [{"Name": "Alice"}], "together": [["Load", "Unload"]], "apart": [["Unload", "Load"]]}`,
+			wantErr: true,
+		},
+		{
+			name:    "team chore kept together",
+			content: `{"chores": [{"Name": "Load"}, {"Name": "Garage", "Headcount": 2}], "people": [{"Name": "Alice"}], "together": [["Load", "Garage"]]}`,
+			wantErr: true,
+		},
+		{
+			name:    "chore in two together groups",
+			content: `{"chores": [{"Name": "A"}, {"Name": "B"}, {"Name": "C"}], "people": [{"Name": "Alice"}], "together": [["A", "B"], ["B", "C"]]}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown rated chore",
+			content: `{"chores": [{"Name": "Dishes"}], "people": [{"Name": "Alice", "Preferences": {"Dishs": "love"}}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid rating",
+			content: `{"chores": [{"Name": "Dishes"}], "people": [{"Name": "Alice", "Preferences": {"Dishes": "meh"}}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "negative preference tolerance",
+			content: `{"chores": [{"Name": "Dishes"}], "people": [{"Name": "Alice"}], "preferenceTolerance": -1}`,
+			wantErr: true,
+		},
+		{
+			name:    "negative auction budget",
+			content: `{"chores": [{"Name": "Dishes"}], "people": [{"Name": "Alice"}], "auctionBudget": -5}`,
+			wantErr: true,
+		},
+		{
+			name:    "surge without a max",
+			content: `{"chores": [{"Name": "Dishes"}], "people": [{"Name": "Alice"}], "surge": {"weeks": 4}}`,
+			wantErr: true,
+		},
+		{
+			name:    "negative surge step",
+			content: `{"chores": [{"Name": "Dishes"}], "people": [{"Name": "Alice"}], "surge": {"step": -1, "max": 3}}`,
+			wantErr: true,
+		},
+		{
+			name:    "negative surge weeks",
+			content: `{"chores": [{"Name": "Dishes"}], "people": [{"Name": "Alice"}], "surge": {"weeks": -2, "max": 3}}`,
+			wantErr: true,
+		},
+		{
+			name:    "day capacity given twice",
+			content: `{"chores": [{"Name": "Dishes"}], "people": [{"Name": "Alice", "DayCapacity": {"Sat": 4, "Saturday": 6}}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown denied person",
+			content: `{"chores": [{"Name": "Mow", "DeniedPeople": ["Bobby"]}], "people": [{"Name": "Alice"}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpfile, err := os.CreateTemp("", "test_validation_*.json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(tmpfile.Name())
+
+			if _, err := tmpfile.Write([]byte(tt.content)); err != nil {
+				t.Fatal(err)
+			}
+			if err := tmpfile.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			config, err := Load(tmpfile.Name())
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected an error for an invalid config")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to load config: %v", err)
+			}
+			if config.Chores[0].MinAge != 12 || config.People[0].Skills[0] != "mowing" {
+				t.Errorf("Eligibility fields not loaded: %+v %+v", config.Chores[0], config.People[0])
+			}
+		})
+	}
+}
+
+func TestLoadBids(t *testing.T) {
+	cfg := &models.Config{
+		Chores: []models.Chore{{Name: "Dishes"}, {Name: "Mow"}},
+		People: []models.Person{{Name: "Alice"}, {Name: "Bob"}},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "valid", content: `{"Alice": {"Dishes": 2, "Mow": 0}, "Bob": {"Mow": 7}}`},
+		{name: "unknown person", content: `{"Carol": {"Dishes": 2}}`, wantErr: true},
+		{name: "unknown chore", content: `{"Alice": {"Laundry": 2}}`, wantErr: true},
+		{name: "negative bid", content: `{"Alice": {"Dishes": -1}}`, wantErr: true},
+		{name: "invalid JSON", content: `{"Alice": {"Dishes": "two"}}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpfile, err := os.CreateTemp("", "test_bids_*.json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(tmpfile.Name())
+
+			if _, err := tmpfile.Write([]byte(tt.content)); err != nil {
+				t.Fatal(err)
+			}
+			if err := tmpfile.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			bids, err := LoadBids(tmpfile.Name(), cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected an error for invalid bids")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to load bids: %v", err)
+			}
+			if bids["Bob"]["Mow"] != 7 || bids["Alice"]["Mow"] != 0 {
+				t.Errorf("Bids not loaded: %v", bids)
+			}
+		})
+	}
+}
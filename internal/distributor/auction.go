@@ -0,0 +1,166 @@
+package distributor
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// Bids are the prices people would accept for chores, by person name and then chore
+// name. A chore missing from someone's bids is one they won't do.
+type Bids map[string]map[string]int
+
+// bid returns what the person asked for every chore in the unit, and false if they
+// didn't bid on all of them.
+func (b Bids) bid(person string, unit []models.Chore) (price int, ok bool) {
+	for _, chore := range unit {
+		p, found := b[person][chore.Name]
+		if !found {
+			return 0, false
+		}
+		price += p
+	}
+	return price, true
+}
+
+// Auction runs a reverse auction: each chore, largest first, goes to whoever asked the
+// least for it and can take it, with ties going to the lowest weighted score and then
+// at random. The winning bid is the chore's price, and replaces its Earned. Chores
+// nobody bid on, and chores whose lowest bid doesn't fit in what is left of budget
+// (0 for no limit), are left unassigned. Team chores are handed out first at their
+// usual Earned, the same way as by the greedy strategy, and don't count toward the
+// budget; chores kept together are auctioned as one.
+func Auction(chores []models.Chore, people []models.Person, rng *rand.Rand, opts Options, bids Bids, budget int) *models.DistributionResult {
+	result := &models.DistributionResult{
+		People:   clonePeople(people),
+		Strategy: "auction",
+		Weights:  opts.Weights,
+		Budget:   &models.Budget{Limit: budget},
+	}
+	assigned := result.People
+	chores, result.Unassigned = opts.assignTeams(chores, assigned, rng)
+
+	for _, unit := range opts.bundle(sortChores(chores, rng, opts.Weights)) {
+		var candidates []int
+		lowest, minScore := math.MaxInt, math.Inf(1)
+		for i := range assigned {
+			price, ok := bids.bid(assigned[i].Name, unit)
+			if !ok || !opts.canTakeAll(assigned[i], unit) {
+				continue
+			}
+			score := Score(assigned[i], opts.Weights)
+			switch {
+			case price < lowest || (price == lowest && score < minScore && !sameScore(score, minScore)):
+				lowest, minScore = price, score
+				candidates = []int{i}
+			case price == lowest && sameScore(score, minScore):
+				candidates = append(candidates, i)
+			}
+		}
+
+		left := budget - result.Budget.Spent
+		if len(candidates) == 0 || (budget > 0 && lowest > left) {
+			result.Unassigned = append(result.Unassigned, newAuctionUnassigned(unit, assigned, opts, bids, lowest, left)...)
+			continue
+		}
+
+		winner := candidates[rng.IntN(len(candidates))]
+		opts.decide(unit, assigned, []int{winner}, candidates, fmt.Sprintf("lowest bid, $%d", lowest))
+		for _, chore := range unit {
+			chore.Earned = bids[assigned[winner].Name][chore.Name]
+			opts.assign(&assigned[winner], chore)
+		}
+		result.Budget.Spent += lowest
+	}
+
+	result.Fairness = ComputeFairness(assigned)
+	return result
+}
+
+// newAuctionUnassigned records the chores in a unit that no one won, and why each
+// person didn't.
+func newAuctionUnassigned(unit []models.Chore, people []models.Person, opts Options, bids Bids, lowest, left int) []models.UnassignedChore {
+	var excluded []models.Exclusion
+	bidders := 0
+	for _, person := range people {
+		price, ok := bids.bid(person.Name, unit)
+		reason := "no bid"
+		if ok {
+			bidders++
+			if reason = opts.whyNotAll(person, unit); reason == "" {
+				reason = fmt.Sprintf("bid $%d", price)
+			}
+		}
+		excluded = append(excluded, models.Exclusion{Person: person.Name, Reason: reason})
+	}
+
+	reason := "no one who bid can take it"
+	switch {
+	case bidders == 0:
+		reason = "no one bid on it"
+	case lowest != math.MaxInt:
+		reason = fmt.Sprintf("lowest bid of $%d is more than the $%d left in the budget", lowest, left)
+	}
+
+	var unassigned []models.UnassignedChore
+	for _, chore := range unit {
+		unassigned = append(unassigned, models.UnassignedChore{
+			Chore:    chore,
+			Reason:   reason,
+			Excluded: excluded,
+		})
+	}
+	return unassigned
+}
+
+// PromptBids asks each person, in turn, what they would accept for each chore they
+// are eligible for, reading answers from in and writing questions to out. A blank
+// answer means they won't do it.
+func PromptBids(in io.Reader, out io.Writer, chores []models.Chore, people []models.Person, opts Options) (Bids, error) {
+	// Instances of a recurring chore share a bid
+	var unique []models.Chore
+	for _, chore := range chores {
+		if !slices.ContainsFunc(unique, func(c models.Chore) bool { return c.Name == chore.Name }) {
+			unique = append(unique, chore)
+		}
+	}
+
+	bids := make(Bids)
+	scanner := bufio.NewScanner(in)
+	fmt.Fprintf(out, "\n=== Chore Auction ===\n\nEnter the least you'd take for each chore, or leave it blank to pass.\n")
+	for _, person := range people {
+		fmt.Fprintf(out, "\n%s:\n", person.Name)
+		bids[person.Name] = make(map[string]int)
+		for _, chore := range unique {
+			if !opts.eligible(person, chore) {
+				continue
+			}
+			for {
+				fmt.Fprintf(out, "  %s (usually $%d): $", chore.Name, chore.Earned)
+				if !scanner.Scan() {
+					fmt.Fprintln(out)
+					return bids, scanner.Err()
+				}
+				input := strings.TrimPrefix(strings.TrimSpace(scanner.Text()), "$")
+				if input == "" {
+					break
+				}
+				price, err := strconv.Atoi(input)
+				if err != nil || price < 0 {
+					fmt.Fprintln(out, "  Please enter a whole number of dollars, or leave it blank to pass")
+					continue
+				}
+				bids[person.Name][chore.Name] = price
+				break
+			}
+		}
+	}
+	return bids, nil
+}
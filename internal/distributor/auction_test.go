@@ -0,0 +1,125 @@
+package distributor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+func TestAuction_LowestBidWins(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+	chores := []models.Chore{
+		{Name: "Mow", Earned: 6, Difficulty: 8},
+		{Name: "Dishes", Earned: 3, Difficulty: 3},
+		{Name: "Trash", Earned: 2, Difficulty: 1},
+	}
+	bids := Bids{
+		"Alice": {"Mow": 9, "Dishes": 2},
+		"Bob":   {"Mow": 7, "Dishes": 4},
+	}
+
+	result := Auction(chores, people, NewRand(1), DefaultOptions(), bids, 0)
+
+	want := map[string]string{"Mow": "Bob", "Dishes": "Alice"}
+	for chore, person := range want {
+		if got := holders(result, chore); len(got) != 1 || got[0] != person {
+			t.Errorf("Expected %s to win %s, got %v", person, chore, got)
+		}
+	}
+	if result.People[1].TotalEarned != 7 || result.People[0].TotalEarned != 2 {
+		t.Errorf("Expected winners to earn their bids, got %+v", result.People)
+	}
+	if len(result.Unassigned) != 1 || result.Unassigned[0].Reason != "no one bid on it" {
+		t.Errorf("Expected Trash unassigned with no bids, got %+v", result.Unassigned)
+	}
+	if result.Strategy != "auction" || result.Budget == nil || result.Budget.Spent != 9 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestAuction_Budget(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+	}
+	chores := []models.Chore{
+		{Name: "Mow", Earned: 6, Difficulty: 8},
+		{Name: "Dishes", Earned: 3, Difficulty: 3},
+	}
+	bids := Bids{"Alice": {"Mow": 8, "Dishes": 3}}
+
+	// Mow goes first as the largest chore and leaves $2, which isn't enough for Dishes
+	result := Auction(chores, people, NewRand(1), DefaultOptions(), bids, 10)
+	if got := holders(result, "Mow"); len(got) != 1 {
+		t.Errorf("Expected Mow to be won, got %v", got)
+	}
+	if len(result.Unassigned) != 1 || !strings.Contains(result.Unassigned[0].Reason, "$3 is more than the $2 left") {
+		t.Errorf("Expected Dishes over budget, got %+v", result.Unassigned)
+	}
+	if result.Budget.Spent != 8 || result.Budget.Limit != 10 {
+		t.Errorf("Unexpected budget: %+v", result.Budget)
+	}
+}
+
+func TestAuction_HardConstraints(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}, EffortCapacity: 4},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+	chores := []models.Chore{
+		{Name: "Mow", Earned: 6, Difficulty: 8},
+		{Name: "Load Dishwasher", Earned: 3, Difficulty: 2},
+		{Name: "Unload Dishwasher", Earned: 2, Difficulty: 2},
+	}
+	// Alice bids lowest on everything, but can't fit Mow, and the dishwasher chores
+	// are kept together, so she must win both or neither
+	bids := Bids{
+		"Alice": {"Mow": 1, "Load Dishwasher": 1, "Unload Dishwasher": 1},
+		"Bob":   {"Mow": 5, "Load Dishwasher": 1},
+	}
+	opts := DefaultOptions()
+	opts.Together = [][]string{{"Load Dishwasher", "Unload Dishwasher"}}
+
+	result := Auction(chores, people, NewRand(1), opts, bids, 0)
+	want := map[string]string{"Mow": "Bob", "Load Dishwasher": "Alice", "Unload Dishwasher": "Alice"}
+	for chore, person := range want {
+		if got := holders(result, chore); len(got) != 1 || got[0] != person {
+			t.Errorf("Expected %s to win %s, got %v", person, chore, got)
+		}
+	}
+	if len(result.Unassigned) != 0 {
+		t.Errorf("Expected everything assigned, got %+v", result.Unassigned)
+	}
+}
+
+func TestPromptBids(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Age: 8},
+		{Name: "Bob", Age: 14},
+	}
+	chores := []models.Chore{
+		{Name: "Mow", Earned: 6, MinAge: 12},
+		{Name: "Dishes", Earned: 3},
+		{Name: "Dishes", Earned: 3},
+	}
+
+	// Alice isn't asked about Mow, and Dishes is asked once per person
+	var out bytes.Buffer
+	bids, err := PromptBids(strings.NewReader("two\n$2\n7\n\n"), &out, chores, people, DefaultOptions())
+	if err != nil {
+		t.Fatalf("PromptBids failed: %v", err)
+	}
+	if len(bids["Alice"]) != 1 || bids["Alice"]["Dishes"] != 2 {
+		t.Errorf("Unexpected bids for Alice: %v", bids["Alice"])
+	}
+	if len(bids["Bob"]) != 1 || bids["Bob"]["Mow"] != 7 {
+		t.Errorf("Unexpected bids for Bob: %v", bids["Bob"])
+	}
+	if !strings.Contains(out.String(), "Please enter a whole number") {
+		t.Errorf("Expected the bad bid to be rejected, got:\n%s", out.String())
+	}
+}
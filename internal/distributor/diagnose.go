@@ -0,0 +1,351 @@
+package distributor
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+	"github.com/faradayfan/chore-distributor/internal/recurrence"
+)
+
+// Kinds of fix, from the least to the most drastic.
+const (
+	fixCapacity = iota
+	fixEligibility
+	fixHeadcount
+	fixDrop
+)
+
+// fix is a change to the chores or people that might let more chores be assigned.
+// Fixes with the same key change the same thing, so a later one replaces an earlier
+// one in the diagnosis.
+type fix struct {
+	key         string
+	description string
+	kind        int
+	amount      int // how big a change of its kind it is
+	apply       func(chores []models.Chore, people []models.Person) ([]models.Chore, []models.Person)
+	// resize, if set, makes the same change by a different amount, so a raise can be
+	// cut down to the least that helps.
+	resize func(amount int) fix
+}
+
+// diagnoseBudget cuts the optimal search short for each trial, so a diagnosis stays
+// quick.
+var diagnoseBudget = OptimalOptions{MaxNodes: 20_000, TimeLimit: 100 * time.Millisecond}
+
+// Diagnose works out a short list of changes that would get every chore assigned when
+// distributed by the strategy with the seed: raising someone's capacity, making
+// someone eligible for a chore, taking one person fewer for a team chore or, as a last
+// resort, dropping a chore this week. It tries every change that could help with the
+// chores left unassigned, keeps the least drastic one that gets more of them assigned,
+// and repeats until they all are. It returns nil if nothing is left unassigned.
+//
+// Each step distributes the chores again for every change it tries, about one per
+// person (more for day limits) for each chore left unassigned, plus a few more to cut
+// each raise that helps down to the smallest that does, and there are at most as many
+// steps as chores. The optimal strategy's search is cut short on each of these
+// runs (see diagnoseBudget), so a diagnosis stays quick.
+func Diagnose(strategy Strategy, chores []models.Chore, people []models.Person, seed uint64, opts Options) []string {
+	opts.Trace = nil
+	if o, ok := strategy.(Optimal); ok {
+		o.Budget = diagnoseBudget
+		strategy = o
+	}
+	run := func(chores []models.Chore, people []models.Person) *models.DistributionResult {
+		return strategy.Distribute(chores, people, NewRand(seed), opts)
+	}
+
+	original := people
+	result := run(chores, people)
+
+	// helps runs the distribution again with the fix, and reports whether it gets more
+	// chores assigned
+	helps := func(f fix) (*models.DistributionResult, bool) {
+		trial := run(f.apply(chores, people))
+		return trial, len(trial.Unassigned) < len(result.Unassigned)
+	}
+
+	var keys, changes []string
+	for steps := 0; len(result.Unassigned) > 0 && steps <= len(chores); steps++ {
+		var best *fix
+		var bestResult *models.DistributionResult
+		for _, f := range opts.fixes(result, chores, people, original) {
+			trial, ok := helps(f)
+			if !ok {
+				continue
+			}
+			// A raise is cut down to the smallest that still helps
+			if f.resize != nil {
+				lo, hi := 1, f.amount
+				for lo < hi {
+					mid := (lo + hi) / 2
+					if smaller, ok := helps(f.resize(mid)); ok {
+						hi, trial = mid, smaller
+					} else {
+						lo = mid + 1
+					}
+				}
+				f = f.resize(hi)
+			}
+			if best == nil || f.kind < best.kind || (f.kind == best.kind && f.amount < best.amount) {
+				best, bestResult = &f, trial
+			}
+		}
+		if best == nil {
+			break
+		}
+
+		chores, people = best.apply(chores, people)
+		result = bestResult
+		if i := slices.Index(keys, best.key); i >= 0 {
+			changes[i] = best.description
+		} else {
+			keys = append(keys, best.key)
+			changes = append(changes, best.description)
+		}
+	}
+	return changes
+}
+
+// fixes lists the changes that might get one of the result's unassigned chores
+// assigned. people are as they were before the distribution, and original as they
+// were before any fixes.
+func (o Options) fixes(result *models.DistributionResult, chores []models.Chore, people, original []models.Person) []fix {
+	var fixes []fix
+	seen := make(map[string]bool)
+	for _, u := range SortedUnassigned(result.Unassigned) {
+		chore := u.Chore
+		if seen[chore.Label()] {
+			continue
+		}
+		seen[chore.Label()] = true
+
+		if n := TeamSize(chore); n > 1 {
+			fixes = append(fixes, headcountFix(chore, n))
+		}
+		// The smallest share of a team chore is the least anyone on the team takes, and
+		// whoever takes a chore kept with others takes them all
+		share := Shares(chore)[TeamSize(chore)-1]
+		need := share.Difficulty
+		if key := o.togetherKey(chore); key != "" {
+			need = 0
+			for _, other := range result.Unassigned {
+				if o.togetherKey(other.Chore) == key {
+					need += other.Chore.Difficulty
+				}
+			}
+		}
+		for i, person := range people {
+			if Ineligibility(person, share, o.date()) != "" {
+				if f, ok := o.eligibilityFix(chore, person); ok {
+					fixes = append(fixes, f)
+				}
+				continue
+			}
+			// People in the result are in the same order, holding what they were given
+			given := result.People[i]
+			if left := given.EffortCapacity - given.TotalDifficulty; given.EffortCapacity > 0 && left < need {
+				fixes = append(fixes, capacityFix(i, original[i].EffortCapacity, person.EffortCapacity, need-left, person.Name))
+			}
+			if !o.hasDay(given, share) {
+				if person.DailyCapacity > 0 {
+					fixes = append(fixes, dailyCapacityFix(i, original[i].DailyCapacity, person.DailyCapacity, need, person.Name))
+				}
+				fixes = append(fixes, o.dayCapacityFixes(i, given, original[i], share, need)...)
+			}
+		}
+		fixes = append(fixes, dropFix(chore))
+	}
+	return fixes
+}
+
+// capacityFix raises person i's EffortCapacity by raise. It was from before any
+// fixes, and is current now.
+func capacityFix(i, from, current, raise int, name string) fix {
+	return fix{
+		resize:      func(raise int) fix { return capacityFix(i, from, current, raise, name) },
+		key:         "capacity\x00" + name,
+		description: fmt.Sprintf("raise %s's EffortCapacity from %d to %d", name, from, current+raise),
+		kind:        fixCapacity,
+		amount:      raise,
+		apply: func(chores []models.Chore, people []models.Person) ([]models.Chore, []models.Person) {
+			people = clonePeople(people)
+			people[i].EffortCapacity += raise
+			return chores, people
+		},
+	}
+}
+
+// dailyCapacityFix raises person i's DailyCapacity by raise. It was from before any
+// fixes, and is current now.
+func dailyCapacityFix(i, from, current, raise int, name string) fix {
+	return fix{
+		resize:      func(raise int) fix { return dailyCapacityFix(i, from, current, raise, name) },
+		key:         "daily\x00" + name,
+		description: fmt.Sprintf("raise %s's DailyCapacity from %d to %d", name, from, current+raise),
+		kind:        fixCapacity,
+		amount:      raise,
+		apply: func(chores []models.Chore, people []models.Person) ([]models.Chore, []models.Person) {
+			people = clonePeople(people)
+			people[i].DailyCapacity += raise
+			return chores, people
+		},
+	}
+}
+
+// dayCapacityFixes raise person i's DayCapacity on each day the chore could go on that
+// has an entry of its own, by just enough to fit need more. given is the person holding
+// what they were given, and original as they were before any fixes. A day set to 0 is
+// a day off, so it's left alone.
+func (o Options) dayCapacityFixes(i int, given, original models.Person, chore models.Chore, need int) []fix {
+	start := o.weekStart()
+	loads := dayLoads(given, start)
+	fixed := dayOffset(start, chore.Date)
+
+	var fixes []fix
+	for d := 0; d < 7; d++ {
+		day := start.AddDate(0, 0, d).Weekday()
+		if (fixed != noDay && d != fixed) || !recurrence.AllowedOn(chore, day) {
+			continue
+		}
+		name, ok := dayCapacityName(given, day)
+		current := given.DayCapacity[name]
+		if !ok || current == 0 {
+			continue
+		}
+		raise := need - max(o.room(given, loads, d), 0)
+		fixes = append(fixes, dayCapacityFix(i, name, day, original.DayCapacity[name], current, raise, given.Name))
+	}
+	return fixes
+}
+
+// dayCapacityFix raises person i's DayCapacity for the day, given under name, by raise.
+// It was from before any fixes, and is current now.
+func dayCapacityFix(i int, name string, day time.Weekday, from, current, raise int, person string) fix {
+	return fix{
+		key:         "day\x00" + person + "\x00" + day.String(),
+		description: fmt.Sprintf("raise %s's DayCapacity for %s from %d to %d", person, name, from, current+raise),
+		kind:        fixCapacity,
+		amount:      raise,
+		apply: func(chores []models.Chore, people []models.Person) ([]models.Chore, []models.Person) {
+			people = clonePeople(people)
+			people[i].DayCapacity = maps.Clone(people[i].DayCapacity)
+			people[i].DayCapacity[name] += raise
+			return chores, people
+		},
+		resize: func(raise int) fix { return dayCapacityFix(i, name, day, from, current, raise, person) },
+	}
+}
+
+// eligibilityFix makes the person eligible for every instance of the chore, if
+// anything but their age stands in the way, or their age is known.
+func (o Options) eligibilityFix(chore models.Chore, person models.Person) (fix, bool) {
+	var steps []string
+	allow := len(chore.AllowedPeople) > 0 && !slices.Contains(chore.AllowedPeople, person.Name)
+	if allow {
+		steps = append(steps, fmt.Sprintf("add %s to %s's AllowedPeople", person.Name, chore.Name))
+	}
+	deny := slices.Contains(chore.DeniedPeople, person.Name)
+	if deny {
+		steps = append(steps, fmt.Sprintf("take %s off %s's DeniedPeople", person.Name, chore.Name))
+	}
+	minAge := chore.MinAge
+	if age, ok := AgeOn(person, o.date()); chore.MinAge > 0 && (!ok || age < chore.MinAge) {
+		if !ok {
+			return fix{}, false
+		}
+		minAge = age
+		steps = append(steps, fmt.Sprintf("lower %s's MinAge from %d to %d", chore.Name, chore.MinAge, age))
+	}
+	var missing []string
+	for _, skill := range chore.Skills {
+		if !slices.Contains(person.Skills, skill) {
+			missing = append(missing, skill)
+		}
+	}
+	if len(missing) > 0 {
+		steps = append(steps, fmt.Sprintf("give %s the %s skill", person.Name, strings.Join(missing, ", ")))
+	}
+
+	return fix{
+		key:         "eligible\x00" + person.Name + "\x00" + chore.Name,
+		description: strings.Join(steps, " and "),
+		kind:        fixEligibility,
+		amount:      len(steps),
+		apply: func(chores []models.Chore, people []models.Person) ([]models.Chore, []models.Person) {
+			chores = slices.Clone(chores)
+			for k := range chores {
+				if chores[k].Name != chore.Name {
+					continue
+				}
+				if allow {
+					chores[k].AllowedPeople = append(slices.Clone(chores[k].AllowedPeople), person.Name)
+				}
+				if deny {
+					chores[k].DeniedPeople = slices.DeleteFunc(slices.Clone(chores[k].DeniedPeople), func(name string) bool { return name == person.Name })
+				}
+				chores[k].MinAge = minAge
+			}
+			people = clonePeople(people)
+			for i := range people {
+				if people[i].Name == person.Name {
+					people[i].Skills = append(slices.Clone(people[i].Skills), missing...)
+				}
+			}
+			return chores, people
+		},
+	}, true
+}
+
+// headcountFix takes one person fewer for every instance of a team chore of n.
+func headcountFix(chore models.Chore, n int) fix {
+	return fix{
+		key:         "headcount\x00" + chore.Name,
+		description: fmt.Sprintf("lower %s's Headcount from %d to %d", chore.Name, n, n-1),
+		kind:        fixHeadcount,
+		amount:      1,
+		apply: func(chores []models.Chore, people []models.Person) ([]models.Chore, []models.Person) {
+			chores = slices.Clone(chores)
+			for k := range chores {
+				if chores[k].Name == chore.Name {
+					chores[k].Headcount = n - 1
+				}
+			}
+			return chores, people
+		},
+	}
+}
+
+// dropFix leaves out one instance of the chore this week.
+func dropFix(chore models.Chore) fix {
+	return fix{
+		key:         "drop\x00" + chore.Label(),
+		description: "drop " + chore.Label(),
+		kind:        fixDrop,
+		apply: func(chores []models.Chore, people []models.Person) ([]models.Chore, []models.Person) {
+			k := slices.IndexFunc(chores, func(c models.Chore) bool {
+				return c.Name == chore.Name && c.Date.Equal(chore.Date)
+			})
+			if k >= 0 {
+				chores = slices.Delete(slices.Clone(chores), k, k+1)
+			}
+			return chores, people
+		},
+	}
+}
+
+// PrintDiagnosis writes the changes Diagnose suggests, if there are any.
+func PrintDiagnosis(w io.Writer, changes []string) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nTo get every chore assigned, you could:")
+	for _, change := range changes {
+		fmt.Fprintf(w, "  - %s\n", change)
+	}
+}
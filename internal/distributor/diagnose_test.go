@@ -0,0 +1,141 @@
+package distributor
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+func TestDiagnose(t *testing.T) {
+	tests := []struct {
+		name   string
+		chores []models.Chore
+		people []models.Person
+		want   []string
+	}{
+		{
+			name: "everything assigned",
+			chores: []models.Chore{
+				{Name: "Dishes", Earned: 3, Difficulty: 3},
+			},
+			people: []models.Person{
+				{Name: "Alice", EffortCapacity: 5},
+			},
+			want: nil,
+		},
+		{
+			name: "raise capacity",
+			chores: []models.Chore{
+				{Name: "Kitchen", Earned: 5, Difficulty: 5},
+				{Name: "Mud Room", Earned: 1, Difficulty: 1},
+			},
+			people: []models.Person{
+				{Name: "Kristen", EffortCapacity: 5},
+			},
+			want: []string{"raise Kristen's EffortCapacity from 5 to 6"},
+		},
+		{
+			name: "raise a day's capacity",
+			chores: []models.Chore{
+				{Name: "Mow", Earned: 3, Difficulty: 3, Days: []string{"Sat"}},
+			},
+			people: []models.Person{
+				{Name: "Alice", DayCapacity: map[string]int{"Saturday": 2}},
+			},
+			want: []string{"raise Alice's DayCapacity for Saturday from 2 to 3"},
+		},
+		{
+			name: "raise daily capacity no more than needed",
+			chores: []models.Chore{
+				{Name: "Sweep", Earned: 5, Difficulty: 1, Days: []string{"Mon"}},
+				{Name: "Mop", Earned: 2, Difficulty: 2, Days: []string{"Mon"}},
+			},
+			people: []models.Person{
+				{Name: "Alice", DailyCapacity: 2},
+			},
+			want: []string{"raise Alice's DailyCapacity from 2 to 3"},
+		},
+		{
+			name: "leave a day off alone",
+			chores: []models.Chore{
+				{Name: "Mow", Earned: 3, Difficulty: 3, Days: []string{"Sat"}},
+			},
+			people: []models.Person{
+				{Name: "Alice", DayCapacity: map[string]int{"Sat": 0}},
+			},
+			want: []string{"drop Mow"},
+		},
+		{
+			name: "make someone eligible",
+			chores: []models.Chore{
+				{Name: "Mow", Earned: 6, Difficulty: 6, MinAge: 12},
+			},
+			people: []models.Person{
+				{Name: "Alice", Age: 10},
+				{Name: "Bob", Age: 8},
+			},
+			want: []string{"lower Mow's MinAge from 12 to 10"},
+		},
+		{
+			name: "drop a chore nobody can take",
+			chores: []models.Chore{
+				{Name: "Mow", Earned: 6, Difficulty: 6, MinAge: 12},
+			},
+			people: []models.Person{
+				{Name: "Alice"},
+			},
+			want: []string{"drop Mow"},
+		},
+		{
+			name: "lower headcount",
+			chores: []models.Chore{
+				{Name: "Garage", Earned: 6, Difficulty: 6, Headcount: 3},
+			},
+			people: []models.Person{
+				{Name: "Alice"},
+				{Name: "Bob"},
+			},
+			want: []string{"lower Garage's Headcount from 3 to 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := range tt.people {
+				tt.people[i].Chores = []models.Chore{}
+			}
+			got := Diagnose(Greedy{}, tt.chores, tt.people, 1, DefaultOptions())
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDiagnose_LeavesInputAlone(t *testing.T) {
+	people := []models.Person{{Name: "Kristen", EffortCapacity: 5, Chores: []models.Chore{}}}
+	chores := []models.Chore{
+		{Name: "Kitchen", Earned: 5, Difficulty: 5, AllowedPeople: []string{"Bob"}},
+	}
+
+	Diagnose(Greedy{}, chores, people, 1, DefaultOptions())
+	if people[0].EffortCapacity != 5 || len(chores) != 1 || len(chores[0].AllowedPeople) != 1 {
+		t.Errorf("Expected the chores and people to be unchanged, got %+v and %+v", chores, people)
+	}
+}
+
+func TestPrintDiagnosis(t *testing.T) {
+	var buf bytes.Buffer
+	PrintDiagnosis(&buf, nil)
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing printed without changes, got %q", buf.String())
+	}
+
+	PrintDiagnosis(&buf, []string{"drop Mow"})
+	if !strings.Contains(buf.String(), "To get every chore assigned, you could:\n  - drop Mow\n") {
+		t.Errorf("Unexpected diagnosis:\n%s", buf.String())
+	}
+}
@@ -3,103 +3,286 @@ package distributor
 import (
 	"fmt"
 	"io"
+	"math"
 	"math/rand/v2"
+	"slices"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/faradayfan/chore-distributor/internal/models"
 )
 
 type PrintOptions struct {
-	Verbose bool 
+	Verbose bool
 }
 
-func Distribute(chores []models.Chore, people []models.Person) []models.Person {
-	sortedChores := make([]models.Chore, len(chores))
-	copy(sortedChores, chores)
+// NewSeed returns a random, non-zero seed for NewRand.
+func NewSeed() uint64 {
+	for {
+		if seed := rand.Uint64(); seed != 0 {
+			return seed
+		}
+	}
+}
 
-	rand.Shuffle(len(sortedChores), func(i, j int) {
-		sortedChores[i], sortedChores[j] = sortedChores[j], sortedChores[i]
-	})
+// NewRand returns a random source that produces the same sequence for the same seed,
+// so a distribution can be regenerated exactly.
+func NewRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
 
-	sort.SliceStable(sortedChores, func(i, j int) bool {
-		return sortedChores[i].Earned > sortedChores[j].Earned
-	})
+// Distribute assigns the chores to people, balancing total earnings while respecting
+// each person's EffortCapacity. The people passed in are not modified; the returned
+// result holds copies with their assigned chores and updated totals.
+func Distribute(chores []models.Chore, people []models.Person, rng *rand.Rand) *models.DistributionResult {
+	return distributeGreedy(chores, people, rng, DefaultOptions())
+}
 
-	for _, chore := range sortedChores {
-		var candidates []int
-		minEarned := -1
+// distributeGreedy gives each chore, largest first, to the person with the lowest
+// weighted score (plus any rotation penalty for that chore) who is eligible for it and
+// has capacity for it, breaking ties at random. Team chores are handed out first, and
+// chores kept together go out as one, to someone who can take them all.
+func distributeGreedy(chores []models.Chore, people []models.Person, rng *rand.Rand, opts Options) *models.DistributionResult {
+	result := &models.DistributionResult{
+		People:   clonePeople(people),
+		Strategy: "greedy",
+		Weights:  opts.Weights,
+	}
+	assigned := result.People
+	chores, result.Unassigned = opts.assignTeams(chores, assigned, rng)
 
-		for i := 0; i < len(people); i++ {
-			hasCapacity := people[i].EffortCapacity == 0 ||
-				(people[i].TotalDifficulty+chore.Difficulty <= people[i].EffortCapacity)
+	for _, bundle := range opts.bundle(sortChores(chores, rng, opts.Weights)) {
+		var candidates []int
+		minScore := math.Inf(1)
 
-			if !hasCapacity {
+		for i := 0; i < len(assigned); i++ {
+			if !opts.canTakeAll(assigned[i], bundle) {
 				continue
 			}
 
-			if minEarned == -1 || people[i].TotalEarned < minEarned {
-				minEarned = people[i].TotalEarned
-				candidates = []int{i}
-			} else if people[i].TotalEarned == minEarned {
+			score := Score(assigned[i], opts.Weights) + opts.bundleCost(assigned[i], bundle)
+			if sameScore(score, minScore) {
 				candidates = append(candidates, i)
+			} else if score < minScore {
+				minScore = score
+				candidates = []int{i}
 			}
 		}
 
 		if len(candidates) == 0 {
-			fmt.Printf("Warning: Could not assign chore '%s' - no one has capacity\n", chore.Name)
+			result.Unassigned = append(result.Unassigned, newBundleUnassigned(bundle, assigned, opts)...)
+			continue
+		}
+
+		minIndex := candidates[rng.IntN(len(candidates))]
+		opts.decide(bundle, assigned, []int{minIndex}, candidates, opts.scoreRule())
+		for _, chore := range bundle {
+			opts.assign(&assigned[minIndex], chore)
+		}
+	}
+
+	result.Fairness = ComputeFairness(assigned)
+	return result
+}
+
+// sortChores returns a shuffled copy of the chores ordered largest first, so chores of
+// the same size come out in a different order each run.
+func sortChores(chores []models.Chore, rng *rand.Rand, w models.Weights) []models.Chore {
+	sorted := make([]models.Chore, len(chores))
+	copy(sorted, chores)
+
+	rng.Shuffle(len(sorted), func(i, j int) {
+		sorted[i], sorted[j] = sorted[j], sorted[i]
+	})
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return choreSize(sorted[i], w) > choreSize(sorted[j], w)
+	})
+
+	return sorted
+}
+
+func hasCapacity(person models.Person, chore models.Chore) bool {
+	return person.EffortCapacity == 0 ||
+		person.TotalDifficulty+chore.Difficulty <= person.EffortCapacity
+}
+
+// SortedUnassigned returns the unassigned chores by date, then name, so they list in
+// the same order whatever order the random choices left them in.
+func SortedUnassigned(unassigned []models.UnassignedChore) []models.UnassignedChore {
+	sorted := slices.Clone(unassigned)
+	slices.SortStableFunc(sorted, func(a, b models.UnassignedChore) int {
+		if c := a.Chore.Date.Compare(b.Chore.Date); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Chore.Name, b.Chore.Name)
+	})
+	return sorted
+}
+
+// newUnassigned records a chore that none of the people could take, and why each
+// of them could not.
+func newUnassigned(chore models.Chore, people []models.Person, opts Options) models.UnassignedChore {
+	var excluded []models.Exclusion
+	eligible, apart := 0, 0
+	for _, person := range people {
+		reason := Ineligibility(person, chore, opts.date())
+		if reason == "" {
+			eligible++
+			other := opts.apartFrom(person, chore)
+			switch {
+			case !hasCapacity(person, chore):
+				reason = capacityReason(person, chore)
+			case other != "":
+				reason = apartReason(other)
+				apart++
+			default:
+				reason = opts.dayReason(person, chore)
+			}
+		}
+		excluded = append(excluded, models.Exclusion{
+			Person: person.Name,
+			Reason: reason,
+		})
+	}
+
+	reason := "no one has capacity"
+	if eligible == 0 {
+		reason = "no one is eligible"
+	} else if apart == eligible {
+		reason = "everyone eligible already has a chore it is kept apart from"
+	}
+	return models.UnassignedChore{
+		Chore:    chore,
+		Reason:   reason,
+		Excluded: excluded,
+	}
+}
+
+func capacityReason(person models.Person, chore models.Chore) string {
+	return fmt.Sprintf("only %d of %d effort capacity left, chore needs %d",
+		person.EffortCapacity-person.TotalDifficulty, person.EffortCapacity, chore.Difficulty)
+}
+
+// formatDaysOff lists days off as "Wed (soccer), Thu (camp)".
+func formatDaysOff(days []models.DayOff) string {
+	parts := make([]string, len(days))
+	for i, day := range days {
+		parts[i] = fmt.Sprintf("%s (%s)", day.Date.Format("Mon"), day.Reason)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printBusy explains how much capacity the person lost to their calendars.
+func printBusy(w io.Writer, person models.Person) {
+	var total time.Duration
+	for _, day := range person.Busy {
+		total += day.Busy
+	}
+	fmt.Fprintf(w, "  Calendar: busy %s this week", formatDuration(total))
+	if person.CalendarLost > 0 {
+		fmt.Fprintf(w, ", -%d effort capacity", person.CalendarLost)
+	}
+	fmt.Fprintln(w)
+
+	for _, day := range person.Busy {
+		fmt.Fprintf(w, "    %s: ", day.Date.Format("Mon"))
+		if day.Full {
+			fmt.Fprintf(w, "busy all day (%s), no chores that day\n", strings.Join(day.Events, ", "))
 			continue
 		}
+		fmt.Fprintf(w, "busy %s (%s)", formatDuration(day.Busy), strings.Join(day.Events, ", "))
+		if day.Lost > 0 {
+			fmt.Fprintf(w, ", -%d capacity that day", day.Lost)
+		}
+		fmt.Fprintln(w)
+	}
+}
 
-		minIndex := candidates[rand.IntN(len(candidates))]
+// formatDuration formats a duration in hours and minutes, such as 1h30m or 45m.
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	}
+	return fmt.Sprintf("%dh%dm", h, m)
+}
 
-		people[minIndex].Chores = append(people[minIndex].Chores, chore)
-		people[minIndex].TotalDifficulty += chore.Difficulty
-		people[minIndex].TotalEarned += chore.Earned
+// signedDollars formats an amount as +$3 or -$3.
+func signedDollars(amount int) string {
+	if amount < 0 {
+		return fmt.Sprintf("-$%d", -amount)
 	}
+	return fmt.Sprintf("+$%d", amount)
+}
 
-	return people
+// clonePeople copies people so that assigning chores never modifies the caller's slice.
+func clonePeople(people []models.Person) []models.Person {
+	cloned := make([]models.Person, len(people))
+	for i, person := range people {
+		cloned[i] = person
+		cloned[i].Chores = append([]models.Chore{}, person.Chores...)
+	}
+	return cloned
+}
+
+// printChore prints a single chore line for person, and its description if it has
+// one. Chores grouped under a day are printed by name, without their date, and team
+// chores say who the person does them with.
+func printChore(w io.Writer, person string, chore models.Chore, opts PrintOptions) {
+	name := chore.NameFor(person)
+	if opts.Verbose {
+		fmt.Fprintf(w, "    - %s (Difficulty: %d, Earns: %s)\n", name, chore.Difficulty, chore.Price())
+	} else {
+		fmt.Fprintf(w, "    - %s (Earns: %s)\n", name, chore.Price())
+	}
+	if chore.Description != "" {
+		fmt.Fprintf(w, "      %s\n", chore.Description)
+	}
 }
 
-func PrintDistribution(w io.Writer, people []models.Person, opts PrintOptions) {
+func PrintDistribution(w io.Writer, result *models.DistributionResult, opts PrintOptions) {
 	fmt.Fprintf(w, "\n=== Chore Distribution ===\n\n")
 
-	for _, person := range people {
+	for _, person := range result.People {
 		fmt.Fprintf(w, "%s", person.Name)
 		if opts.Verbose && person.EffortCapacity > 0 {
 			fmt.Fprintf(w, " (Effort Capacity: %d)", person.EffortCapacity)
 		}
 		fmt.Fprintln(w, ":")
-		fmt.Fprintln(w, "  Chores:")
-		// Print pre-assigned chores first
-		for _, chore := range person.PreAssignedChores {
-			if opts.Verbose {
-				fmt.Fprintf(w, "    - %s (Difficulty: %d, Earns: $%d)\n",
-					chore.Name, chore.Difficulty, chore.Earned)
-				if chore.Description != "" {
-					fmt.Fprintf(w, "      %s\n", chore.Description)
-				}
-			} else {
-				fmt.Fprintf(w, "    - %s (Earns: $%d)\n",
-					chore.Name, chore.Earned)
-				if chore.Description != "" {
-					fmt.Fprintf(w, "      %s\n", chore.Description)
-				}
-			}
+		if opts.Verbose && len(person.DaysOff) > 0 {
+			fmt.Fprintf(w, "  Days Off: %s\n", formatDaysOff(person.DaysOff))
 		}
-		// Then print distributed chores
-		for _, chore := range person.Chores {
-			if opts.Verbose {
-				fmt.Fprintf(w, "    - %s (Difficulty: %d, Earns: $%d)\n",
-					chore.Name, chore.Difficulty, chore.Earned)
-				if chore.Description != "" {
-					fmt.Fprintf(w, "      %s\n", chore.Description)
-				}
-			} else {
-				fmt.Fprintf(w, "    - %s (Earns: $%d)\n",
-					chore.Name, chore.Earned)
-				if chore.Description != "" {
-					fmt.Fprintf(w, "      %s\n", chore.Description)
+		if opts.Verbose && len(person.Busy) > 0 {
+			printBusy(w, person)
+		}
+		// Pre-assigned chores come first, then the distributed chores that can be done
+		// any day, then the rest grouped by the day they are scheduled for
+		anyDay, days := models.ByDay(person.Chores)
+		anyDay = append(append([]models.Chore{}, person.PreAssignedChores...), anyDay...)
+		if len(anyDay) > 0 || len(days) == 0 {
+			fmt.Fprintln(w, "  Chores:")
+		}
+		for _, chore := range anyDay {
+			printChore(w, person.Name, chore, opts)
+		}
+		for _, day := range days {
+			fmt.Fprintf(w, "  %s", day.Date.Format("Monday, Jan 2"))
+			if limit, limited := DayCapacity(person, day.Date.Weekday()); opts.Verbose && limited {
+				load := 0
+				for _, chore := range day.Chores {
+					load += chore.Difficulty
 				}
+				fmt.Fprintf(w, " (Effort: %d / %d)", load, limit)
+			}
+			fmt.Fprintln(w, ":")
+			for _, chore := range day.Chores {
+				printChore(w, person.Name, chore, opts)
 			}
 		}
 		if opts.Verbose {
@@ -110,6 +293,74 @@ func PrintDistribution(w io.Writer, people []models.Person, opts PrintOptions) {
 			fmt.Fprintln(w)
 		}
 		fmt.Fprintf(w, "  Total Earned: $%d\n", person.TotalEarned)
+		if opts.Verbose && person.CarryOver != 0 {
+			fmt.Fprintf(w, "  Carried Over: %s from previous weeks\n", signedDollars(person.CarryOver))
+		}
+		if opts.Verbose && multiObjective(result.Weights) {
+			e, d, c := ScoreComponents(person, result.Weights)
+			fmt.Fprintf(w, "  Score: %.2f (earnings %.2f + difficulty %.2f + chores %.2f)\n", e+d+c, e, d, c)
+		}
+		if opts.Verbose && len(person.Preferences) > 0 {
+			total, loved, hated := Satisfaction(person)
+			fmt.Fprintf(w, "  Satisfaction: %+d (%d loved, %d hated)\n", total, loved, hated)
+		}
 		fmt.Fprintln(w)
 	}
+
+	if len(result.Away) > 0 {
+		fmt.Fprintln(w, "Away This Week:")
+		for _, person := range result.Away {
+			fmt.Fprintf(w, "  - %s: %s\n", person.Name, person.Away)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(result.Unassigned) > 0 {
+		fmt.Fprintln(w, "Unassigned Chores:")
+		for _, u := range SortedUnassigned(result.Unassigned) {
+			fmt.Fprintf(w, "  - %s (Earns: %s): %s\n", u.Chore.Label(), u.Chore.Price(), u.Reason)
+			if opts.Verbose {
+				for _, e := range u.Excluded {
+					fmt.Fprintf(w, "      %s: %s\n", e.Person, e.Reason)
+				}
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(result.Pricing) > 0 {
+		fmt.Fprintln(w, "Surge Pricing:")
+		for _, p := range result.Pricing {
+			fmt.Fprintf(w, "  - %s: $%d + $%d = $%d (%s)\n", p.Chore, p.Base, p.Bonus, p.Base+p.Bonus, p.Reason)
+		}
+		fmt.Fprintln(w)
+	}
+
+	printFairness(w, result)
+
+	if b := result.Budget; b != nil {
+		if b.Limit > 0 {
+			fmt.Fprintf(w, "Budget: $%d of $%d spent\n", b.Spent, b.Limit)
+		} else {
+			fmt.Fprintf(w, "Budget: $%d spent (no limit)\n", b.Spent)
+		}
+	}
+	if opts.Verbose && result.Strategy != "" {
+		fmt.Fprintf(w, "Strategy: %s\n", result.Strategy)
+	}
+	if opts.Verbose && slices.ContainsFunc(result.People, func(p models.Person) bool { return len(p.Preferences) > 0 }) {
+		total := 0
+		for _, person := range result.People {
+			satisfaction, _, _ := Satisfaction(person)
+			total += satisfaction
+		}
+		fmt.Fprintf(w, "Satisfaction: %+d total, with an earnings gap of $%d\n", total, result.Fairness.EarnedSpread)
+	}
+	if opts.Verbose && multiObjective(result.Weights) {
+		fmt.Fprintf(w, "Weights: earnings %g, difficulty %g, chores %g\n",
+			result.Weights.Earnings, result.Weights.Difficulty, result.Weights.Chores)
+	}
+	if result.Seed != 0 {
+		fmt.Fprintf(w, "Seed: %d\n", result.Seed)
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/faradayfan/chore-distributor/internal/models"
 )
@@ -21,21 +22,21 @@ func TestDistribute_BasicDistribution(t *testing.T) {
 		{Name: "Bob", EffortCapacity: 0, Chores: []models.Chore{}},
 	}
 
-	result := Distribute(chores, people)
+	result := Distribute(chores, people, NewRand(1))
 
 	totalChoresAssigned := 0
-	for _, person := range result {
+	for _, person := range result.People {
 		totalChoresAssigned += len(person.Chores)
 	}
 	if totalChoresAssigned != len(chores) {
 		t.Errorf("Expected %d chores assigned, got %d", len(chores), totalChoresAssigned)
 	}
 
-	if len(result) >= 2 {
-		diff := abs(result[0].TotalEarned - result[1].TotalEarned)
+	if len(result.People) >= 2 {
+		diff := abs(result.People[0].TotalEarned - result.People[1].TotalEarned)
 		if diff > 2 {
 			t.Errorf("Earnings not balanced: Alice=$%d, Bob=$%d (diff=$%d)",
-				result[0].TotalEarned, result[1].TotalEarned, diff)
+				result.People[0].TotalEarned, result.People[1].TotalEarned, diff)
 		}
 	}
 }
@@ -52,15 +53,15 @@ func TestDistribute_WithCapacity(t *testing.T) {
 		{Name: "Bob", EffortCapacity: 0, Chores: []models.Chore{}},
 	}
 
-	result := Distribute(chores, people)
+	result := Distribute(chores, people, NewRand(1))
 
-	if result[0].TotalDifficulty > result[0].EffortCapacity {
+	if result.People[0].TotalDifficulty > result.People[0].EffortCapacity {
 		t.Errorf("Alice exceeded capacity: %d > %d",
-			result[0].TotalDifficulty, result[0].EffortCapacity)
+			result.People[0].TotalDifficulty, result.People[0].EffortCapacity)
 	}
 
-	if result[1].TotalDifficulty != 10 {
-		t.Errorf("Bob should have difficulty 10, got %d", result[1].TotalDifficulty)
+	if result.People[1].TotalDifficulty != 10 {
+		t.Errorf("Bob should have difficulty 10, got %d", result.People[1].TotalDifficulty)
 	}
 }
 
@@ -75,11 +76,11 @@ func TestDistribute_NoCapacity(t *testing.T) {
 		{Name: "Bob", EffortCapacity: 0, Chores: []models.Chore{}},
 	}
 
-	result := Distribute(chores, people)
+	result := Distribute(chores, people, NewRand(1))
 
-	if len(result[0].Chores) != 1 || len(result[1].Chores) != 1 {
+	if len(result.People[0].Chores) != 1 || len(result.People[1].Chores) != 1 {
 		t.Errorf("Expected 1 chore each, got Alice=%d, Bob=%d",
-			len(result[0].Chores), len(result[1].Chores))
+			len(result.People[0].Chores), len(result.People[1].Chores))
 	}
 }
 
@@ -93,15 +94,74 @@ func TestDistribute_InsufficientCapacity(t *testing.T) {
 		{Name: "Bob", EffortCapacity: 10, Chores: []models.Chore{}},
 	}
 
-	result := Distribute(chores, people)
+	result := Distribute(chores, people, NewRand(1))
 
 	totalAssigned := 0
-	for _, person := range result {
+	for _, person := range result.People {
 		totalAssigned += len(person.Chores)
 	}
 	if totalAssigned != 0 {
 		t.Errorf("Expected 0 chores assigned when all exceed capacity, got %d", totalAssigned)
 	}
+	if len(result.Unassigned) != 1 {
+		t.Fatalf("Expected 1 unassigned chore, got %d", len(result.Unassigned))
+	}
+	if result.Unassigned[0].Chore.Name != "BigChore" {
+		t.Errorf("Expected BigChore to be unassigned, got %s", result.Unassigned[0].Chore.Name)
+	}
+	if len(result.Unassigned[0].Excluded) != 2 {
+		t.Errorf("Expected both people to be listed as blocked, got %d", len(result.Unassigned[0].Excluded))
+	}
+}
+
+func TestDistribute_DoesNotModifyInput(t *testing.T) {
+	chores := []models.Chore{
+		{Name: "Kitchen", Difficulty: 6, Earned: 5},
+		{Name: "Bathroom", Difficulty: 5, Earned: 4},
+	}
+
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+
+	result := Distribute(chores, people, NewRand(1))
+
+	for _, person := range people {
+		if len(person.Chores) != 0 || person.TotalEarned != 0 || person.TotalDifficulty != 0 {
+			t.Errorf("Input person %s was modified: %+v", person.Name, person)
+		}
+	}
+
+	total := 0
+	for _, person := range result.People {
+		total += len(person.Chores)
+	}
+	if total != len(chores) {
+		t.Errorf("Expected %d chores in the result, got %d", len(chores), total)
+	}
+}
+
+func TestDistribute_Fairness(t *testing.T) {
+	chores := []models.Chore{
+		{Name: "Kitchen", Difficulty: 6, Earned: 5},
+		{Name: "Bathroom", Difficulty: 5, Earned: 4},
+		{Name: "Bedroom", Difficulty: 1, Earned: 1},
+	}
+
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+
+	result := Distribute(chores, people, NewRand(1))
+
+	if result.Fairness.MinEarned != 5 || result.Fairness.MaxEarned != 5 || result.Fairness.EarnedSpread != 0 {
+		t.Errorf("Expected earnings $5-$5 with no spread, got %+v", result.Fairness)
+	}
+	if result.Fairness.DifficultySpread != 0 {
+		t.Errorf("Expected difficulty spread 0, got %d", result.Fairness.DifficultySpread)
+	}
 }
 
 func TestDistribute_SinglePerson(t *testing.T) {
@@ -114,20 +174,20 @@ func TestDistribute_SinglePerson(t *testing.T) {
 		{Name: "Alice", EffortCapacity: 0, Chores: []models.Chore{}},
 	}
 
-	result := Distribute(chores, people)
+	result := Distribute(chores, people, NewRand(1))
 
-	if len(result[0].Chores) != len(chores) {
-		t.Errorf("Expected %d chores, got %d", len(chores), len(result[0].Chores))
+	if len(result.People[0].Chores) != len(chores) {
+		t.Errorf("Expected %d chores, got %d", len(chores), len(result.People[0].Chores))
 	}
 
 	expectedEarned := 6
-	if result[0].TotalEarned != expectedEarned {
-		t.Errorf("Expected total earned $%d, got $%d", expectedEarned, result[0].TotalEarned)
+	if result.People[0].TotalEarned != expectedEarned {
+		t.Errorf("Expected total earned $%d, got $%d", expectedEarned, result.People[0].TotalEarned)
 	}
 
 	expectedDifficulty := 8
-	if result[0].TotalDifficulty != expectedDifficulty {
-		t.Errorf("Expected total difficulty %d, got %d", expectedDifficulty, result[0].TotalDifficulty)
+	if result.People[0].TotalDifficulty != expectedDifficulty {
+		t.Errorf("Expected total difficulty %d, got %d", expectedDifficulty, result.People[0].TotalDifficulty)
 	}
 }
 
@@ -139,9 +199,9 @@ func TestDistribute_EmptyChores(t *testing.T) {
 		{Name: "Bob", EffortCapacity: 0, Chores: []models.Chore{}},
 	}
 
-	result := Distribute(chores, people)
+	result := Distribute(chores, people, NewRand(1))
 
-	for _, person := range result {
+	for _, person := range result.People {
 		if len(person.Chores) != 0 {
 			t.Errorf("Expected no chores for %s, got %d", person.Name, len(person.Chores))
 		}
@@ -159,17 +219,17 @@ func TestDistribute_TotalsCalculatedCorrectly(t *testing.T) {
 		{Name: "Alice", EffortCapacity: 0, Chores: []models.Chore{}},
 	}
 
-	result := Distribute(chores, people)
+	result := Distribute(chores, people, NewRand(1))
 
 	expectedDifficulty := 12
 	expectedEarned := 9
 
-	if result[0].TotalDifficulty != expectedDifficulty {
-		t.Errorf("Expected total difficulty %d, got %d", expectedDifficulty, result[0].TotalDifficulty)
+	if result.People[0].TotalDifficulty != expectedDifficulty {
+		t.Errorf("Expected total difficulty %d, got %d", expectedDifficulty, result.People[0].TotalDifficulty)
 	}
 
-	if result[0].TotalEarned != expectedEarned {
-		t.Errorf("Expected total earned %d, got %d", expectedEarned, result[0].TotalEarned)
+	if result.People[0].TotalEarned != expectedEarned {
+		t.Errorf("Expected total earned %d, got %d", expectedEarned, result.People[0].TotalEarned)
 	}
 }
 
@@ -185,9 +245,9 @@ func TestDistribute_CapacityEdgeCase(t *testing.T) {
 		{Name: "Bob", EffortCapacity: 10, Chores: []models.Chore{}},
 	}
 
-	result := Distribute(chores, people)
+	result := Distribute(chores, people, NewRand(1))
 
-	for _, person := range result {
+	for _, person := range result.People {
 		if person.EffortCapacity > 0 && person.TotalDifficulty > person.EffortCapacity {
 			t.Errorf("%s exceeded capacity: %d > %d",
 				person.Name, person.TotalDifficulty, person.EffortCapacity)
@@ -195,7 +255,7 @@ func TestDistribute_CapacityEdgeCase(t *testing.T) {
 	}
 
 	totalAssigned := 0
-	for _, person := range result {
+	for _, person := range result.People {
 		totalAssigned += len(person.Chores)
 	}
 	if totalAssigned != len(chores) {
@@ -203,6 +263,49 @@ func TestDistribute_CapacityEdgeCase(t *testing.T) {
 	}
 }
 
+func TestDistribute_SameSeedSameResult(t *testing.T) {
+	chores := []models.Chore{
+		{Name: "Kitchen", Difficulty: 6, Earned: 5},
+		{Name: "Bathroom", Difficulty: 5, Earned: 4},
+		{Name: "Living Room", Difficulty: 4, Earned: 3},
+		{Name: "Dining Room", Difficulty: 4, Earned: 3},
+		{Name: "Family Room", Difficulty: 3, Earned: 2},
+		{Name: "Mud Room", Difficulty: 3, Earned: 2},
+	}
+
+	newPeople := func() []models.Person {
+		return []models.Person{
+			{Name: "Alice", Chores: []models.Chore{}},
+			{Name: "Bob", Chores: []models.Chore{}},
+			{Name: "Charlie", Chores: []models.Chore{}},
+		}
+	}
+
+	first := Distribute(chores, newPeople(), NewRand(42))
+	second := Distribute(chores, newPeople(), NewRand(42))
+
+	for i := range first.People {
+		if len(first.People[i].Chores) != len(second.People[i].Chores) {
+			t.Fatalf("%s got %d chores then %d chores with the same seed",
+				first.People[i].Name, len(first.People[i].Chores), len(second.People[i].Chores))
+		}
+		for j := range first.People[i].Chores {
+			if first.People[i].Chores[j].Name != second.People[i].Chores[j].Name {
+				t.Errorf("%s chore %d differs with the same seed: %s vs %s",
+					first.People[i].Name, j, first.People[i].Chores[j].Name, second.People[i].Chores[j].Name)
+			}
+		}
+	}
+}
+
+func TestNewSeed_NonZero(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if NewSeed() == 0 {
+			t.Fatal("NewSeed should never return 0")
+		}
+	}
+}
+
 func TestPrintDistribution_DefaultMode(t *testing.T) {
 	people := []models.Person{
 		{
@@ -215,7 +318,7 @@ func TestPrintDistribution_DefaultMode(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	PrintDistribution(&buf, people, PrintOptions{Verbose: false})
+	PrintDistribution(&buf, &models.DistributionResult{People: people}, PrintOptions{Verbose: false})
 	output := buf.String()
 
 	if !strings.Contains(output, "Earns: $5") {
@@ -248,7 +351,7 @@ func TestPrintDistribution_VerboseMode(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	PrintDistribution(&buf, people, PrintOptions{Verbose: true})
+	PrintDistribution(&buf, &models.DistributionResult{People: people}, PrintOptions{Verbose: true})
 	output := buf.String()
 
 	if !strings.Contains(output, "Earns: $5") {
@@ -277,7 +380,7 @@ func TestPrintDistribution_VerboseNoCapacity(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	PrintDistribution(&buf, people, PrintOptions{Verbose: true})
+	PrintDistribution(&buf, &models.DistributionResult{People: people}, PrintOptions{Verbose: true})
 	output := buf.String()
 
 	if !strings.Contains(output, "Total Difficulty: 6") {
@@ -306,7 +409,7 @@ func TestPrintDistribution_WithDescription(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	PrintDistribution(&buf, people, PrintOptions{Verbose: false})
+	PrintDistribution(&buf, &models.DistributionResult{People: people}, PrintOptions{Verbose: false})
 	output := buf.String()
 
 	if !strings.Contains(output, "Kitchen") {
@@ -348,7 +451,7 @@ func TestPrintDistribution_VerboseWithDescription(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	PrintDistribution(&buf, people, PrintOptions{Verbose: true})
+	PrintDistribution(&buf, &models.DistributionResult{People: people}, PrintOptions{Verbose: true})
 	output := buf.String()
 
 	if !strings.Contains(output, "Living Room") {
@@ -379,7 +482,7 @@ func TestPrintDistribution_WithPreAssignedChores(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	PrintDistribution(&buf, people, PrintOptions{Verbose: false})
+	PrintDistribution(&buf, &models.DistributionResult{People: people}, PrintOptions{Verbose: false})
 	output := buf.String()
 
 	if !strings.Contains(output, "Clean Bedroom") {
@@ -403,6 +506,137 @@ func TestPrintDistribution_WithPreAssignedChores(t *testing.T) {
 	}
 }
 
+func TestPrintDistribution_Unassigned(t *testing.T) {
+	result := &models.DistributionResult{
+		People: []models.Person{
+			{Name: "Alice", EffortCapacity: 5},
+		},
+		Unassigned: []models.UnassignedChore{
+			{
+				Chore:  models.Chore{Name: "Garage", Difficulty: 8, Earned: 6},
+				Reason: "no one has capacity",
+				Excluded: []models.Exclusion{
+					{Person: "Alice", Reason: "only 5 of 5 effort capacity left, chore needs 8"},
+				},
+			},
+		},
+		Seed: 99,
+	}
+
+	var buf bytes.Buffer
+	PrintDistribution(&buf, result, PrintOptions{Verbose: false})
+	output := buf.String()
+
+	if !strings.Contains(output, "Unassigned Chores:") {
+		t.Error("Output should list unassigned chores")
+	}
+	if !strings.Contains(output, "Garage (Earns: $6): no one has capacity") {
+		t.Error("Output should contain the unassigned chore and reason")
+	}
+	if strings.Contains(output, "only 5 of 5") {
+		t.Error("Default output should not contain per-person capacity details")
+	}
+	if !strings.Contains(output, "Seed: 99") {
+		t.Error("Output should contain the seed")
+	}
+
+	buf.Reset()
+	PrintDistribution(&buf, result, PrintOptions{Verbose: true})
+	if !strings.Contains(buf.String(), "Alice: only 5 of 5 effort capacity left") {
+		t.Error("Verbose output should explain which capacity limits blocked the chore")
+	}
+}
+
+func TestSortedUnassigned(t *testing.T) {
+	monday := time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+	unassigned := []models.UnassignedChore{
+		{Chore: models.Chore{Name: "Dishes", Date: monday.AddDate(0, 0, 1)}},
+		{Chore: models.Chore{Name: "Trash", Date: monday}},
+		{Chore: models.Chore{Name: "Mow"}},
+		{Chore: models.Chore{Name: "Dishes", Date: monday}},
+		{Chore: models.Chore{Name: "Garage"}},
+	}
+
+	var got []string
+	for _, u := range SortedUnassigned(unassigned) {
+		got = append(got, u.Chore.Label())
+	}
+	want := []string{"Garage", "Mow", "Dishes (Mon Jun 2)", "Trash (Mon Jun 2)", "Dishes (Tue Jun 3)"}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if unassigned[0].Chore.Name != "Dishes" || unassigned[2].Chore.Name != "Mow" {
+		t.Error("Expected the unassigned chores passed in to be left in order")
+	}
+}
+
+func TestPrintDistribution_Away(t *testing.T) {
+	result := &models.DistributionResult{
+		People: []models.Person{
+			{
+				Name:    "Alice",
+				Chores:  []models.Chore{{Name: "Kitchen", Difficulty: 6, Earned: 5}},
+				DaysOff: []models.DayOff{{Date: time.Date(2025, time.June, 4, 0, 0, 0, 0, time.UTC), Reason: "soccer"}},
+			},
+		},
+		Away: []models.Person{{Name: "Bob", Away: "camp"}},
+	}
+
+	var buf bytes.Buffer
+	PrintDistribution(&buf, result, PrintOptions{Verbose: false})
+	output := buf.String()
+
+	if !strings.Contains(output, "Away This Week:\n  - Bob: camp") {
+		t.Errorf("Output should list who is away and why, got:\n%s", output)
+	}
+	if strings.Contains(output, "Days Off") {
+		t.Error("Default output should not list days off")
+	}
+
+	buf.Reset()
+	PrintDistribution(&buf, result, PrintOptions{Verbose: true})
+	if !strings.Contains(buf.String(), "Days Off: Wed (soccer)") {
+		t.Errorf("Verbose output should list days off, got:\n%s", buf.String())
+	}
+}
+
+func TestPrintDistribution_Busy(t *testing.T) {
+	wed := time.Date(2025, time.June, 4, 0, 0, 0, 0, time.UTC)
+	result := &models.DistributionResult{
+		People: []models.Person{
+			{
+				Name:           "Alice",
+				EffortCapacity: 11,
+				CalendarLost:   3,
+				Busy: []models.BusyDay{
+					{Date: wed, Busy: 3*time.Hour + 30*time.Minute, Events: []string{"Soccer"}, Lost: 2},
+					{Date: wed.AddDate(0, 0, 3), Busy: 14 * time.Hour, Events: []string{"Trip"}, Full: true},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintDistribution(&buf, result, PrintOptions{Verbose: true})
+	output := buf.String()
+
+	for _, want := range []string{
+		"Calendar: busy 17h30m this week, -3 effort capacity",
+		"Wed: busy 3h30m (Soccer), -2 capacity that day",
+		"Sat: busy all day (Trip), no chores that day",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Verbose output should contain %q, got:\n%s", want, output)
+		}
+	}
+
+	buf.Reset()
+	PrintDistribution(&buf, result, PrintOptions{Verbose: false})
+	if strings.Contains(buf.String(), "Calendar") {
+		t.Error("Default output should not explain calendar time")
+	}
+}
+
 func abs(x int) int {
 	if x < 0 {
 		return -x
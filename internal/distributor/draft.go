@@ -0,0 +1,153 @@
+package distributor
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// Draft lets people pick their own chores in a snake draft: in order of their current
+// earnings (including any carry-over), lowest first, each picks one of the remaining
+// chores, and the order reverses every round. Picks are read from in and the board is
+// written to out. Someone with no capacity left for any remaining chore, or who isn't
+// eligible for any, is skipped. Team chores are handed out first, the same way as by
+// the greedy strategy, and chores kept together are picked as one.
+//
+// When nobody can pick any of what is left, or in runs out, whatever is left is handed
+// out by fallback among everyone, as it stands after the draft.
+func Draft(in io.Reader, out io.Writer, chores []models.Chore, people []models.Person, rng *rand.Rand, opts Options, fallback Strategy) (*models.DistributionResult, error) {
+	people = clonePeople(people)
+	chores, teamUnassigned := opts.assignTeams(chores, people, rng)
+	units := opts.bundle(sortChores(chores, rng, opts.Weights))
+
+	// Ties in earnings are broken at random
+	order := rng.Perm(len(people))
+	slices.SortStableFunc(order, func(a, b int) int {
+		return balance(people[a]) - balance(people[b])
+	})
+
+	fmt.Fprintf(out, "\n=== Chore Draft ===\n\n")
+	var names []string
+	for _, i := range order {
+		names = append(names, fmt.Sprintf("%s ($%d)", people[i].Name, balance(people[i])))
+	}
+	fmt.Fprintf(out, "Pick order: %s. The order reverses every round.\n", strings.Join(names, ", "))
+
+	scanner := bufio.NewScanner(in)
+	ended := false
+	for round := 1; len(units) > 0 && !ended; round++ {
+		turns := slices.Clone(order)
+		if round%2 == 0 {
+			slices.Reverse(turns)
+		}
+
+		picked := false
+		for _, i := range turns {
+			if len(units) == 0 || !slices.ContainsFunc(units, func(unit []models.Chore) bool {
+				return opts.canTakeAll(people[i], unit)
+			}) {
+				continue
+			}
+			if !picked {
+				fmt.Fprintf(out, "\nRound %d\n", round)
+				picked = true
+			}
+
+			u, ok, err := pick(scanner, out, people[i], units, opts)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				ended = true
+				break
+			}
+			opts.decide(units[u], people, []int{i}, nil, "picked it in the draft")
+			for _, chore := range units[u] {
+				opts.assign(&people[i], chore)
+			}
+			fmt.Fprintf(out, "%s takes %s\n", people[i].Name, unitLabel(units[u]))
+			units = slices.Delete(units, u, u+1)
+		}
+		if !picked {
+			break
+		}
+	}
+
+	var rest []models.Chore
+	for _, unit := range units {
+		rest = append(rest, unit...)
+	}
+	if len(rest) > 0 {
+		fmt.Fprintf(out, "\nAuto-assigning %d chore(s) nobody picked (%s)\n", len(rest), fallback.Name())
+	}
+
+	result := fallback.Distribute(rest, people, rng, opts)
+	result.Strategy = "draft"
+	result.Unassigned = append(teamUnassigned, result.Unassigned...)
+	return result, nil
+}
+
+// pick asks the person which of the units to take until they choose one they can, and
+// returns its index. ok is false if the input ran out.
+func pick(scanner *bufio.Scanner, out io.Writer, person models.Person, units [][]models.Chore, opts Options) (u int, ok bool, err error) {
+	fmt.Fprintf(out, "\n%s's pick ($%d earned", person.Name, person.TotalEarned)
+	if person.EffortCapacity > 0 {
+		fmt.Fprintf(out, ", %d effort left", person.EffortCapacity-person.TotalDifficulty)
+	}
+	fmt.Fprintln(out, "):")
+	for n, unit := range units {
+		earned, difficulty := 0, 0
+		for _, chore := range unit {
+			earned += chore.Earned
+			difficulty += chore.Difficulty
+		}
+		fmt.Fprintf(out, "  %2d. %s ($%d, difficulty %d)", n+1, unitLabel(unit), earned, difficulty)
+		if !opts.canTakeAll(person, unit) {
+			fmt.Fprint(out, " - can't take")
+		}
+		fmt.Fprintln(out)
+	}
+
+	for {
+		fmt.Fprintf(out, "Pick a number, or \"auto\" for the biggest chore %s can take: ", person.Name)
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return 0, false, scanner.Err()
+		}
+
+		input := strings.TrimSpace(strings.ToLower(scanner.Text()))
+		if input == "a" || input == "auto" {
+			for u, unit := range units {
+				if opts.canTakeAll(person, unit) {
+					return u, true, nil
+				}
+			}
+		}
+
+		n, err := strconv.Atoi(input)
+		if err != nil || n < 1 || n > len(units) {
+			fmt.Fprintf(out, "Please enter a number from 1 to %d, or \"auto\"\n", len(units))
+			continue
+		}
+		if reason := opts.whyNotAll(person, units[n-1]); reason != "" {
+			fmt.Fprintf(out, "%s can't take %s: %s\n", person.Name, unitLabel(units[n-1]), reason)
+			continue
+		}
+		return n - 1, true, nil
+	}
+}
+
+// unitLabel names the chores in a unit, such as "Load Dishwasher + Unload Dishwasher".
+func unitLabel(unit []models.Chore) string {
+	var labels []string
+	for _, chore := range unit {
+		labels = append(labels, chore.Label())
+	}
+	return strings.Join(labels, " + ")
+}
@@ -0,0 +1,101 @@
+package distributor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+func TestDraft_SnakeOrder(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}, TotalEarned: 4},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+	chores := []models.Chore{
+		{Name: "Mow", Earned: 6, Difficulty: 8},
+		{Name: "Kitchen", Earned: 5, Difficulty: 5},
+		{Name: "Dishes", Earned: 3, Difficulty: 3},
+		{Name: "Trash", Earned: 2, Difficulty: 2},
+	}
+
+	// Bob is behind, so picks first: Mow. Then Alice: Kitchen. Round two reverses, so
+	// Alice picks again (Trash, the second of Dishes and Trash), then Bob takes Dishes
+	var out bytes.Buffer
+	result, err := Draft(strings.NewReader("1\n1\n2\nauto\n"), &out, chores, people, NewRand(1), DefaultOptions(), Greedy{})
+	if err != nil {
+		t.Fatalf("Draft failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Pick order: Bob ($0), Alice ($4)") {
+		t.Errorf("Expected Bob to pick first, got:\n%s", out.String())
+	}
+
+	want := map[string]string{"Mow": "Bob", "Kitchen": "Alice", "Trash": "Alice", "Dishes": "Bob"}
+	for chore, person := range want {
+		if got := holders(result, chore); len(got) != 1 || got[0] != person {
+			t.Errorf("Expected %s to pick %s, got %v", person, chore, got)
+		}
+	}
+	if result.Strategy != "draft" || len(result.Unassigned) != 0 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestDraft_EnforcesCapacityAndEligibility(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}, EffortCapacity: 5, Age: 8},
+	}
+	chores := []models.Chore{
+		{Name: "Mow", Earned: 6, Difficulty: 3, MinAge: 12},
+		{Name: "Kitchen", Earned: 5, Difficulty: 8},
+		{Name: "Dishes", Earned: 3, Difficulty: 3},
+	}
+
+	var out bytes.Buffer
+	result, err := Draft(strings.NewReader("1\n2\n9\n3\n"), &out, chores, people, NewRand(1), DefaultOptions(), Greedy{})
+	if err != nil {
+		t.Fatalf("Draft failed: %v", err)
+	}
+	for _, want := range []string{
+		"Alice can't take Mow: age 8, chore requires age 12+",
+		"Alice can't take Kitchen: only 5 of 5 effort capacity left, chore needs 8",
+		"Please enter a number from 1 to 3",
+		"Alice takes Dishes",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected %q in the draft, got:\n%s", want, out.String())
+		}
+	}
+	if len(result.People[0].Chores) != 1 || len(result.Unassigned) != 2 {
+		t.Errorf("Expected Alice to end up with only the dishes, got %+v", result)
+	}
+}
+
+func TestDraft_FallsBackWhenInputEnds(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+	chores := []models.Chore{
+		{Name: "Mow", Earned: 6, Difficulty: 8},
+		{Name: "Kitchen", Earned: 5, Difficulty: 5},
+		{Name: "Dishes", Earned: 3, Difficulty: 3},
+	}
+
+	var out bytes.Buffer
+	result, err := Draft(strings.NewReader("1\n"), &out, chores, people, NewRand(1), DefaultOptions(), Greedy{})
+	if err != nil {
+		t.Fatalf("Draft failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Auto-assigning 2 chore(s) nobody picked (greedy)") {
+		t.Errorf("Expected the rest to be auto-assigned, got:\n%s", out.String())
+	}
+	count := 0
+	for _, person := range result.People {
+		count += len(person.Chores)
+	}
+	if count != 3 || len(result.Unassigned) != 0 {
+		t.Errorf("Expected all 3 chores assigned, got %d and %+v", count, result.Unassigned)
+	}
+}
@@ -0,0 +1,81 @@
+package distributor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// BirthdateLayout is the format of Person.Birthdate.
+const BirthdateLayout = "2006-01-02"
+
+// Ineligibility returns why the person may never take the chore, or "" if they may.
+// Unlike capacity, eligibility does not change as chores are handed out. Ages are
+// worked out as of date.
+func Ineligibility(person models.Person, chore models.Chore, date time.Time) string {
+	if len(chore.AllowedPeople) > 0 && !slices.Contains(chore.AllowedPeople, person.Name) {
+		return "not on the chore's allowed list"
+	}
+	if slices.Contains(chore.DeniedPeople, person.Name) {
+		return "on the chore's denied list"
+	}
+	if chore.MinAge > 0 {
+		age, ok := AgeOn(person, date)
+		if !ok {
+			return fmt.Sprintf("no age set, chore requires age %d+", chore.MinAge)
+		}
+		if age < chore.MinAge {
+			return fmt.Sprintf("age %d, chore requires age %d+", age, chore.MinAge)
+		}
+	}
+	var missing []string
+	for _, skill := range chore.Skills {
+		if !slices.Contains(person.Skills, skill) {
+			missing = append(missing, skill)
+		}
+	}
+	if len(missing) > 0 {
+		return "missing skill: " + strings.Join(missing, ", ")
+	}
+	return ""
+}
+
+// AgeOn returns the person's age in whole years on date, from their Birthdate if set
+// and otherwise their Age. ok is false if neither is set or the Birthdate is invalid.
+func AgeOn(person models.Person, date time.Time) (age int, ok bool) {
+	if person.Birthdate == "" {
+		return person.Age, person.Age > 0
+	}
+	born, err := time.Parse(BirthdateLayout, person.Birthdate)
+	if err != nil {
+		return 0, false
+	}
+	age = date.Year() - born.Year()
+	if date.Month() < born.Month() || (date.Month() == born.Month() && date.Day() < born.Day()) {
+		age--
+	}
+	return age, true
+}
+
+// canTake reports whether the person can be given the chore now: they must be
+// eligible for it, have the capacity left, both for the week and on a day the chore
+// can be scheduled, and not already have a chore it is kept apart from.
+func (o Options) canTake(person models.Person, chore models.Chore) bool {
+	return hasCapacity(person, chore) && o.eligible(person, chore) && o.apartFrom(person, chore) == "" &&
+		o.hasDay(person, chore)
+}
+
+func (o Options) eligible(person models.Person, chore models.Chore) bool {
+	return Ineligibility(person, chore, o.date()) == ""
+}
+
+// date is the day ages are checked against: Options.Date, or today if unset.
+func (o Options) date() time.Time {
+	if o.Date.IsZero() {
+		return time.Now()
+	}
+	return o.Date
+}
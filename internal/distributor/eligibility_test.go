@@ -0,0 +1,88 @@
+package distributor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+func TestAgeOn(t *testing.T) {
+	date := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		person models.Person
+		age    int
+		ok     bool
+	}{
+		{"birthday already passed", models.Person{Birthdate: "2013-05-31"}, 12, true},
+		{"birthday today", models.Person{Birthdate: "2013-06-01"}, 12, true},
+		{"birthday tomorrow", models.Person{Birthdate: "2013-06-02"}, 11, true},
+		{"birthdate wins over age", models.Person{Birthdate: "2013-05-31", Age: 30}, 12, true},
+		{"age only", models.Person{Age: 9}, 9, true},
+		{"neither set", models.Person{}, 0, false},
+		{"invalid birthdate", models.Person{Birthdate: "June 1st"}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			age, ok := AgeOn(tt.person, date)
+			if age != tt.age || ok != tt.ok {
+				t.Errorf("Expected (%d, %v), got (%d, %v)", tt.age, tt.ok, age, ok)
+			}
+		})
+	}
+}
+
+func TestIneligibility(t *testing.T) {
+	date := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	alice := models.Person{Name: "Alice", Age: 10, Skills: []string{"mowing"}}
+
+	tests := []struct {
+		name   string
+		chore  models.Chore
+		reason string
+	}{
+		{"no rules", models.Chore{Name: "Dishes"}, ""},
+		{"allowed", models.Chore{AllowedPeople: []string{"Bob", "Alice"}}, ""},
+		{"not allowed", models.Chore{AllowedPeople: []string{"Bob"}}, "not on the chore's allowed list"},
+		{"denied", models.Chore{DeniedPeople: []string{"Alice"}}, "on the chore's denied list"},
+		{"old enough", models.Chore{MinAge: 10}, ""},
+		{"too young", models.Chore{MinAge: 12}, "age 10, chore requires age 12+"},
+		{"has skill", models.Chore{Skills: []string{"mowing"}}, ""},
+		{"missing skills", models.Chore{Skills: []string{"mowing", "driving", "cooking"}}, "missing skill: driving, cooking"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Ineligibility(alice, tt.chore, date); got != tt.reason {
+				t.Errorf("Expected %q, got %q", tt.reason, got)
+			}
+		})
+	}
+
+	if got := Ineligibility(models.Person{Name: "Bob"}, models.Chore{MinAge: 12}, date); got != "no age set, chore requires age 12+" {
+		t.Errorf("Expected people with no age to be excluded from age-limited chores, got %q", got)
+	}
+}
+
+func TestNewUnassigned_MixedReasons(t *testing.T) {
+	opts := DefaultOptions()
+	chore := models.Chore{Name: "Mow", Difficulty: 5, Earned: 5, Skills: []string{"mowing"}}
+	people := []models.Person{
+		{Name: "Alice", EffortCapacity: 6, TotalDifficulty: 4, Skills: []string{"mowing"}},
+		{Name: "Bob"},
+	}
+
+	u := newUnassigned(chore, people, opts)
+	if u.Reason != "no one has capacity" {
+		t.Errorf("Expected a capacity reason when someone is eligible, got %q", u.Reason)
+	}
+	if u.Excluded[0].Reason != "only 2 of 6 effort capacity left, chore needs 5" {
+		t.Errorf("Unexpected reason for Alice: %q", u.Excluded[0].Reason)
+	}
+	if u.Excluded[1].Reason != "missing skill: mowing" {
+		t.Errorf("Unexpected reason for Bob: %q", u.Excluded[1].Reason)
+	}
+}
@@ -0,0 +1,105 @@
+package distributor
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// Trace collects the decisions made while distributing, when set in Options.
+type Trace struct {
+	Decisions []models.Decision
+}
+
+// decide records in the trace, if there is one, that the chores went to the people at
+// the winners' indexes, chosen by rule. tied holds the indexes of everyone the choice
+// was drawn from at random, if it came to that. people are as they stood just before.
+func (o Options) decide(chores []models.Chore, people []models.Person, winners, tied []int, rule string) {
+	if o.Trace == nil {
+		return
+	}
+
+	decision := models.Decision{
+		Chores: chores,
+		Rule:   rule,
+	}
+	for _, i := range winners {
+		decision.People = append(decision.People, people[i].Name)
+	}
+	if len(tied) > 1 {
+		for _, i := range tied {
+			decision.Tied = append(decision.Tied, people[i].Name)
+		}
+	}
+	for _, person := range people {
+		left := -1
+		if person.EffortCapacity > 0 {
+			left = person.EffortCapacity - person.TotalDifficulty
+		}
+		decision.Considered = append(decision.Considered, models.Consideration{
+			Person:       person.Name,
+			Earned:       person.TotalEarned,
+			CarryOver:    person.CarryOver,
+			CapacityLeft: left,
+			Score:        Score(person, o.Weights) + o.bundleCost(person, chores),
+			Excluded:     o.whyNotAll(person, chores),
+		})
+	}
+	o.Trace.Decisions = append(o.Trace.Decisions, decision)
+}
+
+// scoreRule describes choosing the person with the lowest score.
+func (o Options) scoreRule() string {
+	rule := "lowest earnings so far"
+	if multiObjective(o.Weights) {
+		rule = "lowest weighted score so far"
+	}
+	if o.RotationPenalty != 0 && len(o.RecentChores) > 0 {
+		rule += ", counting the rotation penalty"
+	}
+	return rule
+}
+
+// PrintExplanation writes why each chore went to whoever got it. With a chore name,
+// only the decisions about that chore are written. It reports whether there were any.
+func PrintExplanation(w io.Writer, decisions []models.Decision, chore string) bool {
+	found := false
+	for _, d := range decisions {
+		if chore != "" && !slices.ContainsFunc(d.Chores, func(c models.Chore) bool { return strings.EqualFold(c.Name, chore) }) {
+			continue
+		}
+		if found {
+			fmt.Fprintln(w)
+		}
+		found = true
+
+		fmt.Fprintf(w, "%s → %s: %s\n", unitLabel(d.Chores), strings.Join(d.People, ", "), d.Rule)
+		for _, c := range d.Considered {
+			fmt.Fprintf(w, "  - %s: ", c.Person)
+			if c.Excluded != "" {
+				fmt.Fprintf(w, "can't take it, %s\n", c.Excluded)
+				continue
+			}
+			fmt.Fprintf(w, "$%d earned", c.Earned)
+			if c.CarryOver != 0 {
+				fmt.Fprintf(w, " (%s carried over)", signedDollars(c.CarryOver))
+			}
+			if c.CapacityLeft >= 0 {
+				fmt.Fprintf(w, ", %d effort left", c.CapacityLeft)
+			} else {
+				fmt.Fprint(w, ", no effort limit")
+			}
+			if !sameScore(c.Score, float64(c.Earned+c.CarryOver)) {
+				fmt.Fprintf(w, ", score %.2f", c.Score)
+			}
+			fmt.Fprintln(w)
+		}
+		if len(d.Tied) > 0 {
+			fmt.Fprintf(w, "  %s were tied, so it was drawn at random\n", joinNames(d.Tied))
+		}
+	}
+	return found
+}
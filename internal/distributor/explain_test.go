@@ -0,0 +1,157 @@
+package distributor
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+func TestExplain_Greedy(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}, Age: 14, EffortCapacity: 8},
+		{Name: "Bob", Chores: []models.Chore{}, Age: 9},
+	}
+	chores := []models.Chore{
+		{Name: "Mow", Earned: 6, Difficulty: 6, MinAge: 12},
+		{Name: "Kitchen", Earned: 5, Difficulty: 5},
+	}
+	opts := DefaultOptions()
+	opts.Trace = &Trace{}
+
+	distributeGreedy(chores, people, NewRand(1), opts)
+
+	decisions := opts.Trace.Decisions
+	if len(decisions) != 2 {
+		t.Fatalf("Expected 2 decisions, got %+v", decisions)
+	}
+
+	mow := decisions[0]
+	if mow.Chores[0].Name != "Mow" || mow.People[0] != "Alice" || mow.Rule != "lowest earnings so far" || len(mow.Tied) != 0 {
+		t.Errorf("Unexpected decision: %+v", mow)
+	}
+	if c := mow.Considered[1]; c.Person != "Bob" || c.Excluded != "age 9, chore requires age 12+" {
+		t.Errorf("Expected Bob excluded by age, got %+v", c)
+	}
+
+	// By the time Kitchen is handed out, Alice has earned $6 and has 2 effort left
+	kitchen := decisions[1]
+	if c := kitchen.Considered[0]; c.Earned != 6 || c.CapacityLeft != 2 || !strings.HasPrefix(c.Excluded, "only 2 of 8") {
+		t.Errorf("Expected Alice as she stood after Mow, got %+v", c)
+	}
+	if c := kitchen.Considered[1]; c.CapacityLeft != -1 || c.Excluded != "" {
+		t.Errorf("Expected Bob to be a candidate with no limit, got %+v", c)
+	}
+}
+
+func TestExplain_TieBreak(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+	opts := DefaultOptions()
+	opts.Trace = &Trace{}
+
+	distributeGreedy([]models.Chore{{Name: "Trash", Earned: 1}}, people, NewRand(1), opts)
+
+	if tied := opts.Trace.Decisions[0].Tied; len(tied) != 2 {
+		t.Errorf("Expected Alice and Bob tied, got %v", tied)
+	}
+}
+
+func TestExplain_EveryStrategy(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}, Preferences: map[string]string{"Vacuum": models.Love}},
+		{Name: "Bob", Chores: []models.Chore{}},
+		{Name: "Carol", Chores: []models.Chore{}},
+	}
+	chores := []models.Chore{
+		{Name: "Kitchen", Earned: 5, Difficulty: 5},
+		{Name: "Bathroom", Earned: 4, Difficulty: 4},
+		{Name: "Vacuum", Earned: 3, Difficulty: 3},
+		{Name: "Laundry", Earned: 3, Difficulty: 3},
+		{Name: "Trash", Earned: 1, Difficulty: 1},
+		{Name: "Garage", Earned: 6, Difficulty: 6, Headcount: 2},
+	}
+
+	for _, name := range Strategies() {
+		s, _ := Lookup(name)
+		opts := DefaultOptions()
+		opts.Trace = &Trace{}
+		result := s.Distribute(chores, people, NewRand(1), opts)
+
+		// Every chore handed out is explained exactly once, and given to whoever the
+		// decision says
+		explained := make(map[string][]string)
+		for _, d := range opts.Trace.Decisions {
+			for _, chore := range d.Chores {
+				explained[chore.Name] = append(explained[chore.Name], d.People...)
+			}
+		}
+		for _, chore := range chores {
+			got, want := explained[chore.Name], holders(result, chore.Name)
+			slices.Sort(got)
+			slices.Sort(want)
+			if !slices.Equal(got, want) {
+				t.Errorf("%s: %s was explained as going to %v, but went to %v", name, chore.Name, got, want)
+			}
+		}
+	}
+}
+
+func TestExplain_NoTrace(t *testing.T) {
+	people := []models.Person{{Name: "Alice", Chores: []models.Chore{}}}
+	chores := []models.Chore{{Name: "Trash", Earned: 1}}
+
+	// Tracing must not change the distribution
+	traced := DefaultOptions()
+	traced.Trace = &Trace{}
+	a := distributeGreedy(chores, people, NewRand(1), traced)
+	b := distributeGreedy(chores, people, NewRand(1), DefaultOptions())
+	if a.People[0].TotalEarned != b.People[0].TotalEarned {
+		t.Error("Expected the same distribution with and without a trace")
+	}
+}
+
+func TestPrintExplanation(t *testing.T) {
+	decisions := []models.Decision{
+		{
+			Chores: []models.Chore{{Name: "Bathroom"}},
+			People: []string{"Alice"},
+			Rule:   "lowest earnings so far",
+			Considered: []models.Consideration{
+				{Person: "Alice", Earned: 3, CarryOver: -2, CapacityLeft: 4, Score: 1},
+				{Person: "Bob", Earned: 3, CapacityLeft: -1, Score: 3},
+				{Person: "Carol", Excluded: "age 8, chore requires age 12+"},
+			},
+			Tied: []string{"Alice", "Bob"},
+		},
+		{Chores: []models.Chore{{Name: "Trash"}}, People: []string{"Bob"}, Rule: "next in turn"},
+	}
+
+	var buf bytes.Buffer
+	if !PrintExplanation(&buf, decisions, "bathroom") {
+		t.Fatal("Expected a decision about the bathroom")
+	}
+	output := buf.String()
+	for _, want := range []string{
+		"Bathroom → Alice: lowest earnings so far",
+		"  - Alice: $3 earned (-$2 carried over), 4 effort left\n",
+		"  - Bob: $3 earned, no effort limit\n",
+		"  - Carol: can't take it, age 8, chore requires age 12+",
+		"  Alice and Bob were tied, so it was drawn at random",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Trash") {
+		t.Errorf("Expected only the bathroom, got:\n%s", output)
+	}
+
+	if PrintExplanation(&buf, decisions, "Garage") {
+		t.Error("Expected no decision about the garage")
+	}
+}
@@ -0,0 +1,89 @@
+package distributor
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// ComputeFairness measures how evenly earnings and effort were spread across people:
+// the range of each, the standard deviation and Gini coefficient of earnings, and each
+// person's difficulty per dollar and capacity used.
+func ComputeFairness(people []models.Person) models.Fairness {
+	var f models.Fairness
+	total := 0
+	for i, person := range people {
+		if i == 0 || person.TotalEarned < f.MinEarned {
+			f.MinEarned = person.TotalEarned
+		}
+		if i == 0 || person.TotalEarned > f.MaxEarned {
+			f.MaxEarned = person.TotalEarned
+		}
+		if i == 0 || person.TotalDifficulty < f.MinDifficulty {
+			f.MinDifficulty = person.TotalDifficulty
+		}
+		if i == 0 || person.TotalDifficulty > f.MaxDifficulty {
+			f.MaxDifficulty = person.TotalDifficulty
+		}
+		total += person.TotalEarned
+
+		pf := models.PersonFairness{Name: person.Name}
+		if person.TotalEarned > 0 {
+			pf.DifficultyPerDollar = float64(person.TotalDifficulty) / float64(person.TotalEarned)
+		}
+		if person.EffortCapacity > 0 {
+			pf.CapacityUsed = float64(person.TotalDifficulty) / float64(person.EffortCapacity)
+		}
+		f.People = append(f.People, pf)
+	}
+	f.EarnedSpread = f.MaxEarned - f.MinEarned
+	f.DifficultySpread = f.MaxDifficulty - f.MinDifficulty
+	if len(people) == 0 {
+		return f
+	}
+
+	n := float64(len(people))
+	mean := float64(total) / n
+	variance, differences := 0.0, 0.0
+	for _, a := range people {
+		variance += (float64(a.TotalEarned) - mean) * (float64(a.TotalEarned) - mean)
+		for _, b := range people {
+			differences += math.Abs(float64(a.TotalEarned - b.TotalEarned))
+		}
+	}
+	f.EarnedStdDev = math.Sqrt(variance / n)
+	if total > 0 {
+		f.Gini = differences / (2 * n * float64(total))
+	}
+	return f
+}
+
+// printFairness writes the fairness summary at the foot of a distribution.
+func printFairness(w io.Writer, result *models.DistributionResult) {
+	// Fairness is measured over result.People, in the same order
+	f := result.Fairness
+	if len(f.People) == 0 || len(f.People) != len(result.People) {
+		return
+	}
+
+	fmt.Fprintln(w, "Fairness:")
+	fmt.Fprintf(w, "  Earnings: $%d to $%d (gap $%d, std dev $%.2f, Gini %.2f)\n",
+		f.MinEarned, f.MaxEarned, f.EarnedSpread, f.EarnedStdDev, f.Gini)
+	fmt.Fprintf(w, "  Difficulty: %d to %d (gap %d)\n", f.MinDifficulty, f.MaxDifficulty, f.DifficultySpread)
+	for i, p := range f.People {
+		fmt.Fprintf(w, "  - %s: ", p.Name)
+		if result.People[i].TotalEarned > 0 {
+			fmt.Fprintf(w, "%.2f difficulty per $1", p.DifficultyPerDollar)
+		} else {
+			fmt.Fprint(w, "nothing earned")
+		}
+		if result.People[i].EffortCapacity > 0 {
+			fmt.Fprintf(w, ", %.0f%% of capacity used\n", 100*p.CapacityUsed)
+		} else {
+			fmt.Fprintln(w, ", no capacity limit")
+		}
+	}
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,84 @@
+package distributor
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+func TestComputeFairness(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", TotalEarned: 2, TotalDifficulty: 4, EffortCapacity: 8},
+		{Name: "Bob", TotalEarned: 6, TotalDifficulty: 3},
+		{Name: "Carol", TotalEarned: 0, TotalDifficulty: 0, EffortCapacity: 5},
+	}
+
+	f := ComputeFairness(people)
+	if f.MinEarned != 0 || f.MaxEarned != 6 || f.EarnedSpread != 6 || f.DifficultySpread != 4 {
+		t.Errorf("Unexpected ranges: %+v", f)
+	}
+	// Mean earnings are $8/3; the pairwise differences add up to 2*(4+2+6) = 24
+	if want := math.Sqrt(56.0 / 9); math.Abs(f.EarnedStdDev-want) > 1e-9 {
+		t.Errorf("Expected std dev %.4f, got %.4f", want, f.EarnedStdDev)
+	}
+	if want := 24.0 / (2 * 3 * 8); math.Abs(f.Gini-want) > 1e-9 {
+		t.Errorf("Expected Gini %.4f, got %.4f", want, f.Gini)
+	}
+
+	want := []models.PersonFairness{
+		{Name: "Alice", DifficultyPerDollar: 2, CapacityUsed: 0.5},
+		{Name: "Bob", DifficultyPerDollar: 0.5},
+		{Name: "Carol"},
+	}
+	if len(f.People) != len(want) {
+		t.Fatalf("Expected %d people, got %+v", len(want), f.People)
+	}
+	for i := range want {
+		if f.People[i] != want[i] {
+			t.Errorf("Expected %+v, got %+v", want[i], f.People[i])
+		}
+	}
+}
+
+func TestComputeFairness_Even(t *testing.T) {
+	f := ComputeFairness([]models.Person{
+		{Name: "Alice", TotalEarned: 5},
+		{Name: "Bob", TotalEarned: 5},
+	})
+	if f.EarnedStdDev != 0 || f.Gini != 0 {
+		t.Errorf("Expected no spread, got %+v", f)
+	}
+
+	if f := ComputeFairness(nil); f.Gini != 0 || len(f.People) != 0 {
+		t.Errorf("Expected nothing measured without people, got %+v", f)
+	}
+}
+
+func TestPrintDistribution_Fairness(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}, EffortCapacity: 10},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+	chores := []models.Chore{
+		{Name: "Kitchen", Difficulty: 6, Earned: 5},
+		{Name: "Bathroom", Difficulty: 5, Earned: 3},
+	}
+	result := Distribute(chores, people, NewRand(1))
+
+	var buf bytes.Buffer
+	PrintDistribution(&buf, result, PrintOptions{})
+	output := buf.String()
+
+	for _, want := range []string{
+		"Fairness:\n  Earnings: $3 to $5 (gap $2, std dev $1.00, Gini 0.12)\n  Difficulty: 5 to 6 (gap 1)\n",
+		"of capacity used\n",
+		", no capacity limit\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package distributor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// keptApart reports whether two chores (by name) may not go to the same person.
+func (o Options) keptApart(a, b string) bool {
+	if a == b {
+		return false
+	}
+	for _, group := range o.Apart {
+		if slices.Contains(group, a) && slices.Contains(group, b) {
+			return true
+		}
+	}
+	return false
+}
+
+// apartFrom returns the name of a chore the person already has that the chore is kept
+// apart from, or "" if there is none.
+func (o Options) apartFrom(person models.Person, chore models.Chore) string {
+	if len(o.Apart) == 0 {
+		return ""
+	}
+	for _, held := range slices.Concat(person.PreAssignedChores, person.Chores) {
+		if o.keptApart(held.Name, chore.Name) {
+			return held.Name
+		}
+	}
+	return ""
+}
+
+func apartReason(other string) string {
+	return fmt.Sprintf("already has %s, which is kept apart from it", other)
+}
+
+// whyNot explains why the person can't be given the chore now, or returns "" if they
+// can.
+func (o Options) whyNot(person models.Person, chore models.Chore) string {
+	if reason := Ineligibility(person, chore, o.date()); reason != "" {
+		return reason
+	}
+	if !hasCapacity(person, chore) {
+		return capacityReason(person, chore)
+	}
+	if other := o.apartFrom(person, chore); other != "" {
+		return apartReason(other)
+	}
+	if !o.hasDay(person, chore) {
+		return o.dayReason(person, chore)
+	}
+	return ""
+}
+
+// togetherKey identifies the chores that must go to the same person: those in the
+// same Together group due on the same day (or not due on a particular day). It is ""
+// for a chore in no group.
+func (o Options) togetherKey(chore models.Chore) string {
+	for g, group := range o.Together {
+		if slices.Contains(group, chore.Name) {
+			return fmt.Sprintf("%d\x00%s", g, chore.Date.Format(time.DateOnly))
+		}
+	}
+	return ""
+}
+
+// bundle splits chores into the sets that must go to one person, keeping their order:
+// each set is placed where its first chore was. Chores in no Together group are on
+// their own.
+func (o Options) bundle(chores []models.Chore) [][]models.Chore {
+	bundles := make([][]models.Chore, 0, len(chores))
+	index := make(map[string]int)
+	for _, chore := range chores {
+		key := o.togetherKey(chore)
+		if b, ok := index[key]; ok && key != "" {
+			bundles[b] = append(bundles[b], chore)
+			continue
+		}
+		index[key] = len(bundles)
+		bundles = append(bundles, []models.Chore{chore})
+	}
+	return bundles
+}
+
+// canTakeAll reports whether the person can be given every chore in the bundle.
+func (o Options) canTakeAll(person models.Person, bundle []models.Chore) bool {
+	if len(bundle) == 1 {
+		return o.canTake(person, bundle[0])
+	}
+	return o.whyNotAll(person, bundle) == ""
+}
+
+// whyNotAll explains why the person can't be given every chore in the bundle, or
+// returns "" if they can.
+func (o Options) whyNotAll(person models.Person, bundle []models.Chore) string {
+	person.Chores = slices.Clone(person.Chores)
+	for _, chore := range bundle {
+		if reason := o.whyNot(person, chore); reason != "" {
+			if len(bundle) > 1 {
+				reason = chore.Label() + ": " + reason
+			}
+			return reason
+		}
+		o.assign(&person, chore)
+	}
+	return ""
+}
+
+// bundleCost is the rotation penalty for giving the person every chore in the bundle.
+func (o Options) bundleCost(person models.Person, bundle []models.Chore) float64 {
+	cost := 0.0
+	for _, chore := range bundle {
+		cost += o.rotationCost(person, chore)
+	}
+	return cost
+}
+
+// bundleRepeats is how many times the person had the bundle's chores recently.
+func (o Options) bundleRepeats(person models.Person, bundle []models.Chore) int {
+	n := 0
+	for _, chore := range bundle {
+		n += o.repeats(person, chore)
+	}
+	return n
+}
+
+// newBundleUnassigned records the chores in a bundle that none of the people could
+// take all of, and why each of them could not.
+func newBundleUnassigned(bundle []models.Chore, people []models.Person, opts Options) []models.UnassignedChore {
+	if len(bundle) == 1 {
+		return []models.UnassignedChore{newUnassigned(bundle[0], people, opts)}
+	}
+
+	var excluded []models.Exclusion
+	for _, person := range people {
+		excluded = append(excluded, models.Exclusion{
+			Person: person.Name,
+			Reason: opts.whyNotAll(person, bundle),
+		})
+	}
+
+	var unassigned []models.UnassignedChore
+	for _, chore := range bundle {
+		var others []string
+		for _, other := range bundle {
+			if other.Name != chore.Name && !slices.Contains(others, other.Name) {
+				others = append(others, other.Name)
+			}
+		}
+		unassigned = append(unassigned, models.UnassignedChore{
+			Chore:    chore,
+			Reason:   fmt.Sprintf("kept together with %s, and no one can take them all", strings.Join(others, ", ")),
+			Excluded: excluded,
+		})
+	}
+	return unassigned
+}
@@ -0,0 +1,135 @@
+package distributor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// holders returns the names of the people who got a chore.
+func holders(result *models.DistributionResult, chore string) []string {
+	var names []string
+	for _, person := range result.People {
+		for _, c := range person.Chores {
+			if c.Name == chore {
+				names = append(names, person.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+func TestGroups_Together(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}},
+		{Name: "Carol", Chores: []models.Chore{}},
+	}
+	chores := []models.Chore{
+		{Name: "Load Dishwasher", Earned: 3, Difficulty: 2},
+		{Name: "Unload Dishwasher", Earned: 2, Difficulty: 2},
+		{Name: "Vacuum", Earned: 4, Difficulty: 3},
+		{Name: "Laundry", Earned: 3, Difficulty: 3},
+		{Name: "Trash", Earned: 1, Difficulty: 1},
+	}
+	opts := DefaultOptions()
+	opts.Together = [][]string{{"Load Dishwasher", "Unload Dishwasher"}}
+
+	for _, strategy := range []string{"greedy", "round-robin", "optimal"} {
+		s, _ := Lookup(strategy)
+		for seed := uint64(1); seed <= 20; seed++ {
+			result := s.Distribute(chores, people, NewRand(seed), opts)
+			load, unload := holders(result, "Load Dishwasher"), holders(result, "Unload Dishwasher")
+			if len(load) != 1 || len(unload) != 1 || load[0] != unload[0] {
+				t.Fatalf("%s, seed %d: expected the dishwasher chores to go to one person, got %v and %v",
+					strategy, seed, load, unload)
+			}
+		}
+	}
+}
+
+func TestGroups_TogetherByDay(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+	var chores []models.Chore
+	for d := 0; d < 2; d++ {
+		date := planStart.AddDate(0, 0, d)
+		chores = append(chores,
+			models.Chore{Name: "Load Dishwasher", Earned: 2, Difficulty: 1, Date: date},
+			models.Chore{Name: "Unload Dishwasher", Earned: 1, Difficulty: 1, Date: date})
+	}
+	opts := DefaultOptions()
+	opts.Date = planStart
+	opts.Together = [][]string{{"Load Dishwasher", "Unload Dishwasher"}}
+
+	result := distributeGreedy(chores, people, NewRand(1), opts)
+	for _, person := range result.People {
+		if len(person.Chores) != 2 || !person.Chores[0].Date.Equal(person.Chores[1].Date) {
+			t.Errorf("Expected %s to load and unload on one day, got %+v", person.Name, person.Chores)
+		}
+	}
+}
+
+func TestGroups_Apart(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}, TotalEarned: 100},
+	}
+	chores := []models.Chore{
+		{Name: "Kitchen", Earned: 5, Difficulty: 5},
+		{Name: "Dining Room", Earned: 4, Difficulty: 4},
+	}
+	opts := DefaultOptions()
+	opts.Apart = [][]string{{"Kitchen", "Dining Room"}}
+
+	for _, strategy := range []string{"greedy", "round-robin", "optimal"} {
+		s, _ := Lookup(strategy)
+		result := s.Distribute(chores, people, NewRand(1), opts)
+		kitchen, dining := holders(result, "Kitchen"), holders(result, "Dining Room")
+		if len(kitchen) != 1 || len(dining) != 1 || kitchen[0] == dining[0] {
+			t.Errorf("%s: expected the kitchen and dining room to go to different people, got %v and %v",
+				strategy, kitchen, dining)
+		}
+	}
+}
+
+func TestGroups_Infeasible(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}, EffortCapacity: 3},
+		{Name: "Bob", Chores: []models.Chore{}, EffortCapacity: 3},
+	}
+	chores := []models.Chore{
+		{Name: "Load Dishwasher", Earned: 3, Difficulty: 2},
+		{Name: "Unload Dishwasher", Earned: 2, Difficulty: 2},
+	}
+	opts := DefaultOptions()
+	opts.Together = [][]string{{"Load Dishwasher", "Unload Dishwasher"}}
+
+	for _, strategy := range []string{"greedy", "round-robin", "optimal"} {
+		s, _ := Lookup(strategy)
+		result := s.Distribute(chores, people, NewRand(1), opts)
+		if len(result.Unassigned) != 2 {
+			t.Fatalf("%s: expected both chores unassigned rather than split up, got %+v", strategy, result.People)
+		}
+		u := result.Unassigned[0]
+		if !strings.Contains(u.Reason, "kept together with") {
+			t.Errorf("%s: unexpected reason %q", strategy, u.Reason)
+		}
+		if len(u.Excluded) != 2 || !strings.HasPrefix(u.Excluded[0].Reason, "Unload Dishwasher: only 1 of 3") {
+			t.Errorf("%s: expected each person's reason, got %+v", strategy, u.Excluded)
+		}
+	}
+
+	// With only one person, Kitchen and Dining Room can't both be given out
+	opts = DefaultOptions()
+	opts.Apart = [][]string{{"Kitchen", "Dining Room"}}
+	result := distributeGreedy([]models.Chore{{Name: "Kitchen", Earned: 5}, {Name: "Dining Room", Earned: 4}},
+		people[:1], NewRand(1), opts)
+	if len(result.Unassigned) != 1 || result.Unassigned[0].Reason != "everyone eligible already has a chore it is kept apart from" {
+		t.Errorf("Expected the dining room unassigned, got %+v", result.Unassigned)
+	}
+}
@@ -0,0 +1,525 @@
+package distributor
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// OptimalOptions bounds the branch-and-bound search used by DistributeOptimal.
+type OptimalOptions struct {
+	// MaxNodes is the maximum number of search nodes to visit (0 for no limit).
+	MaxNodes int
+	// TimeLimit is the maximum time to spend searching (0 for no limit).
+	TimeLimit time.Duration
+}
+
+// DefaultOptimalOptions keeps the search fast enough for an interactive run.
+var DefaultOptimalOptions = OptimalOptions{
+	MaxNodes:  2_000_000,
+	TimeLimit: 2 * time.Second,
+}
+
+// DistributeOptimal searches for the assignment that leaves the fewest chores unassigned
+// and, among those, minimizes the spread between the highest and lowest earnings
+// (TotalEarned plus any CarryOver), subject to each person's EffortCapacity and the
+// chores' eligibility rules. The greedy distribution is used as the
+// starting point, so if the search budget runs out the best assignment found so far
+// (at worst the greedy one) is returned.
+func DistributeOptimal(chores []models.Chore, people []models.Person, rng *rand.Rand, budget OptimalOptions) *models.DistributionResult {
+	return Optimal{Budget: budget}.Distribute(chores, people, rng, DefaultOptions())
+}
+
+// Optimal is the branch-and-bound strategy. With weights set it minimizes the weighted
+// sum of the earnings, difficulty and chore-count spreads instead of earnings alone,
+// plus the rotation penalty for every chore given to someone who had it recently.
+type Optimal struct {
+	Budget OptimalOptions
+}
+
+func (Optimal) Name() string { return "optimal" }
+
+func (o Optimal) Distribute(chores []models.Chore, people []models.Person, rng *rand.Rand, opts Options) *models.DistributionResult {
+	// Team chores are handed out first, as by the greedy strategy, and the search
+	// covers the rest
+	people = clonePeople(people)
+	chores, teamUnassigned := opts.assignTeams(chores, people, rng)
+
+	// The greedy distribution's decisions only explain the result if the search can't
+	// improve on it
+	start := opts
+	if opts.Trace != nil {
+		start.Trace = &Trace{}
+	}
+	greedy := distributeGreedy(chores, people, rng, start)
+	greedy.Strategy = "optimal"
+
+	s := newOptimalSearch(chores, people, rng, o.Budget, opts)
+	s.seedIncumbent(greedy)
+	s.search(0)
+
+	result := greedy
+	if s.improved {
+		result = s.result()
+	} else if opts.Trace != nil {
+		opts.Trace.Decisions = append(opts.Trace.Decisions, start.Trace.Decisions...)
+	}
+	result.Unassigned = append(teamUnassigned, result.Unassigned...)
+	return result
+}
+
+func init() {
+	Register(Optimal{Budget: DefaultOptimalOptions})
+}
+
+// loads tracks one quantity (earnings, difficulty or chore count) for every person.
+type loads []int
+
+func (l loads) span() (lo, hi int) {
+	for i, v := range l {
+		if i == 0 || v < lo {
+			lo = v
+		}
+		if i == 0 || v > hi {
+			hi = v
+		}
+	}
+	return lo, hi
+}
+
+// spreadLowerBound returns a spread that no completion of the current partial
+// assignment can beat, given that remaining more units are still to be handed out.
+// The lowest person can at best receive all of them, and the final minimum can never
+// exceed the average.
+func (l loads) spreadLowerBound(remaining int) int {
+	lo, hi := l.span()
+	bound := hi - lo - remaining
+
+	total := remaining
+	for _, v := range l {
+		total += v
+	}
+	if len(l) > 0 {
+		if avgBound := hi - total/len(l); avgBound > bound {
+			bound = avgBound
+		}
+	}
+
+	if bound < 0 {
+		return 0
+	}
+	return bound
+}
+
+type optimalSearch struct {
+	chores    []models.Chore
+	people    []models.Person
+	order     []int // person indices in the order they are tried
+	weights   models.Weights
+	opts      Options
+	budget    OptimalOptions
+	deadline  time.Time
+	remaining struct {
+		earned     []int // earned[k] is the total Earned of chores k..n-1
+		difficulty []int
+	}
+
+	earned     loads
+	difficulty loads
+	count      loads
+	days       [][7]int // days[i] is the difficulty scheduled on each day of the week for person i
+	dayLimits  [][7]int // dayLimits[i] is person i's capacity on each day, -1 for no limit
+	dayLimited bool     // whether anyone has a daily limit, making days matter
+	choice     []int    // person index for each chore, -1 if unassigned
+	eligible   [][]bool // eligible[k][i] reports whether person i may take chore k
+	repeat     []bool   // repeat[k] reports whether chore k is identical to chore k-1
+	lead       []int    // lead[k] is the first chore kept together with chore k, or k
+	bundled    []bool   // bundled[k] reports whether chore k leads others kept with it
+	position   []int    // position[i] is person i's index in order
+	unassigned int
+	penalty    float64 // rotation penalty of the chores assigned so far
+
+	best           []int
+	bestUnassigned int
+	bestCost       float64
+	improved       bool
+
+	nodes   int
+	stopped bool
+}
+
+func newOptimalSearch(chores []models.Chore, people []models.Person, rng *rand.Rand, budget OptimalOptions, opts Options) *optimalSearch {
+	dayLimits := make([][7]int, len(people))
+	dayLimited := false
+	for i, person := range people {
+		for d := range dayLimits[i] {
+			limit, limited := DayCapacity(person, opts.weekStart().AddDate(0, 0, d).Weekday())
+			if !limited {
+				limit = -1
+			}
+			dayLimits[i][d] = limit
+			dayLimited = dayLimited || limited
+		}
+	}
+
+	// Without daily limits, instances of a chore on different days are interchangeable.
+	key := func(chore models.Chore) string {
+		if dayLimited {
+			return fmt.Sprintf("%s\x00%s\x00%s", choreKey(chore), chore.Date.Format(time.DateOnly), strings.Join(chore.Days, ","))
+		}
+		return choreKey(chore)
+	}
+	sorted := groupIdentical(sortChores(chores, rng, opts.Weights), opts.Weights, key)
+
+	s := &optimalSearch{
+		chores:     sorted,
+		people:     people,
+		order:      rng.Perm(len(people)),
+		weights:    opts.Weights,
+		opts:       opts,
+		budget:     budget,
+		earned:     make(loads, len(people)),
+		difficulty: make(loads, len(people)),
+		count:      make(loads, len(people)),
+		days:       make([][7]int, len(people)),
+		dayLimits:  dayLimits,
+		dayLimited: dayLimited,
+		choice:     make([]int, len(sorted)),
+		eligible:   make([][]bool, len(sorted)),
+		repeat:     make([]bool, len(sorted)),
+		lead:       make([]int, len(sorted)),
+		bundled:    make([]bool, len(sorted)),
+		position:   make([]int, len(people)),
+	}
+	for n, i := range s.order {
+		s.position[i] = n
+	}
+	leads := make(map[string]int)
+	for k, chore := range sorted {
+		s.eligible[k] = make([]bool, len(people))
+		for i, person := range people {
+			s.eligible[k][i] = opts.eligible(person, chore) && opts.apartFrom(person, chore) == ""
+		}
+
+		// A chore kept with others goes to whoever its lead went to, so it is never
+		// interchangeable with the one before it
+		together := opts.togetherKey(chore)
+		s.lead[k] = k
+		if lead, ok := leads[together]; ok && together != "" {
+			s.lead[k] = lead
+			s.bundled[lead] = true
+		} else {
+			leads[together] = k
+		}
+		s.repeat[k] = k > 0 && together == "" && key(sorted[k-1]) == key(chore) && slices.Equal(s.eligible[k-1], s.eligible[k])
+	}
+	s.remaining.earned = make([]int, len(sorted)+1)
+	s.remaining.difficulty = make([]int, len(sorted)+1)
+	for k := len(sorted) - 1; k >= 0; k-- {
+		s.remaining.earned[k] = s.remaining.earned[k+1] + sorted[k].Earned
+		s.remaining.difficulty[k] = s.remaining.difficulty[k+1] + sorted[k].Difficulty
+	}
+	for i, person := range people {
+		s.earned[i] = balance(person)
+		s.difficulty[i] = person.TotalDifficulty
+		s.count[i] = len(person.PreAssignedChores) + len(person.Chores)
+		s.days[i] = dayLoads(person, opts.weekStart())
+	}
+	if budget.TimeLimit > 0 {
+		s.deadline = time.Now().Add(budget.TimeLimit)
+	}
+	return s
+}
+
+// seedIncumbent uses an existing distribution as the solution to beat.
+func (s *optimalSearch) seedIncumbent(result *models.DistributionResult) {
+	earned := make(loads, len(result.People))
+	difficulty := make(loads, len(result.People))
+	count := make(loads, len(result.People))
+	penalty := 0.0
+	for i, person := range result.People {
+		earned[i] = balance(person)
+		difficulty[i] = person.TotalDifficulty
+		count[i] = len(person.PreAssignedChores) + len(person.Chores)
+		// Only the chores the search hands out count, not any the people started with
+		for _, chore := range person.Chores[len(s.people[i].Chores):] {
+			penalty += s.opts.rotationCost(person, chore)
+		}
+	}
+
+	s.bestUnassigned = len(result.Unassigned)
+	s.bestCost = s.cost(earned, difficulty, count) + penalty
+}
+
+func (s *optimalSearch) cost(earned, difficulty, count loads) float64 {
+	spread := func(l loads) float64 {
+		lo, hi := l.span()
+		return float64(hi - lo)
+	}
+	return s.weights.Earnings*spread(earned) +
+		s.weights.Difficulty*spread(difficulty) +
+		s.weights.Chores*spread(count)
+}
+
+func (s *optimalSearch) lowerBound(k int) float64 {
+	return s.weights.Earnings*float64(s.earned.spreadLowerBound(s.remaining.earned[k])) +
+		s.weights.Difficulty*float64(s.difficulty.spreadLowerBound(s.remaining.difficulty[k])) +
+		s.weights.Chores*float64(s.count.spreadLowerBound(len(s.chores)-k)) +
+		s.penalty
+}
+
+func (s *optimalSearch) search(k int) {
+	if s.stopped || s.done() {
+		return
+	}
+	s.nodes++
+	if s.budgetExceeded() {
+		s.stopped = true
+		return
+	}
+
+	if s.unassigned > s.bestUnassigned {
+		return
+	}
+	if s.unassigned == s.bestUnassigned && s.lowerBound(k) > s.bestCost-scoreEpsilon {
+		return
+	}
+
+	if k == len(s.chores) {
+		s.record(s.cost(s.earned, s.difficulty, s.count) + s.penalty)
+		return
+	}
+
+	// Identical chores are handed out in search order, so that giving the same
+	// people the same set of identical chores is only tried once.
+	first := 0
+	if s.repeat[k] && s.choice[k-1] >= 0 {
+		first = s.position[s.choice[k-1]]
+	}
+
+	// A chore kept with an earlier one has to follow it
+	if lead := s.lead[k]; lead != k {
+		s.follow(k, s.choice[lead])
+		return
+	}
+
+	// Of identical chores, any left unassigned are the last ones
+	if s.repeat[k] && s.choice[k-1] < 0 {
+		s.leaveOut(k)
+		return
+	}
+
+	chore := s.chores[k]
+	tried := false
+	for n := first; n < len(s.order); n++ {
+		i := s.order[n]
+		if !s.eligible[k][i] || !s.fits(i, chore) || s.apartConflict(k, i) || s.duplicateOfEarlier(k, first, n) {
+			continue
+		}
+
+		day, ok := s.opts.pickDay(s.people[i], s.days[i], chore)
+		if !ok {
+			continue
+		}
+		tried = true
+
+		s.searchWith(k, i, day)
+
+		if s.stopped || s.done() {
+			return
+		}
+	}
+
+	// Leaving out a chore someone could take may still make room for others, so it's
+	// tried too while it could do no worse than the best so far
+	if !tried || s.bundled[k] || s.unassigned < s.bestUnassigned {
+		s.leaveOut(k)
+	}
+}
+
+// leaveOut leaves chore k unassigned and searches on.
+func (s *optimalSearch) leaveOut(k int) {
+	s.choice[k] = -1
+	s.unassigned++
+	s.search(k + 1)
+	s.unassigned--
+}
+
+// follow gives chore k to person i, who has the chore it is kept together with, and
+// searches on. If i is -1 the chore is left unassigned too; if i can't take it, this
+// branch is a dead end.
+func (s *optimalSearch) follow(k, i int) {
+	chore := s.chores[k]
+	if i < 0 {
+		s.leaveOut(k)
+		return
+	}
+	if !s.eligible[k][i] || !s.fits(i, chore) || s.apartConflict(k, i) {
+		return
+	}
+	day, ok := s.opts.pickDay(s.people[i], s.days[i], chore)
+	if !ok {
+		return
+	}
+
+	s.searchWith(k, i, day)
+}
+
+// searchWith gives chore k to person i on the given day and searches on from there.
+func (s *optimalSearch) searchWith(k, i, day int) {
+	chore := s.chores[k]
+	rotation := s.opts.rotationCost(s.people[i], chore)
+	s.choice[k] = i
+	s.earned[i] += chore.Earned
+	s.difficulty[i] += chore.Difficulty
+	s.count[i]++
+	s.penalty += rotation
+	if day != noDay {
+		s.days[i][day] += chore.Difficulty
+	}
+	s.search(k + 1)
+	s.earned[i] -= chore.Earned
+	s.difficulty[i] -= chore.Difficulty
+	s.count[i]--
+	s.penalty -= rotation
+	if day != noDay {
+		s.days[i][day] -= chore.Difficulty
+	}
+}
+
+// apartConflict reports whether person i has been given a chore in the search that
+// chore k is kept apart from.
+func (s *optimalSearch) apartConflict(k, i int) bool {
+	if len(s.opts.Apart) == 0 {
+		return false
+	}
+	for j := 0; j < k; j++ {
+		if s.choice[j] == i && s.opts.keptApart(s.chores[j].Name, s.chores[k].Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *optimalSearch) fits(i int, chore models.Chore) bool {
+	capacity := s.people[i].EffortCapacity
+	return capacity == 0 || s.difficulty[i]+chore.Difficulty <= capacity
+}
+
+// duplicateOfEarlier reports whether the person at position n of the search order is
+// indistinguishable from one tried before them (from position first) at this node, in
+// which case trying them again would only revisit an equivalent branch. People with a
+// rotation history are never interchangeable, and neither are people whose eligibility
+// differs for any of the chores still to be handed out or, when anyone has a daily
+// limit, whose daily limits or schedules differ. With chores kept apart, what people
+// already hold matters too, so no one is treated as interchangeable.
+func (s *optimalSearch) duplicateOfEarlier(k, first, n int) bool {
+	i := s.order[n]
+	if s.hasHistory(i) || len(s.opts.Apart) > 0 {
+		return false
+	}
+	for _, j := range s.order[first:n] {
+		if s.hasHistory(j) || !s.sameEligibility(k, i, j) {
+			continue
+		}
+		if s.earned[j] == s.earned[i] &&
+			s.difficulty[j] == s.difficulty[i] &&
+			s.count[j] == s.count[i] &&
+			s.people[j].EffortCapacity == s.people[i].EffortCapacity &&
+			(!s.dayLimited || (s.days[j] == s.days[i] && s.dayLimits[j] == s.dayLimits[i])) {
+			return true
+		}
+	}
+	return false
+}
+
+// groupIdentical moves identical chores (those with the same key, such as the daily
+// instances of a recurring chore) next to each other without otherwise changing the
+// largest-first order.
+func groupIdentical(chores []models.Chore, w models.Weights, key func(models.Chore) string) []models.Chore {
+	first := make(map[string]int)
+	for k, chore := range chores {
+		if _, ok := first[key(chore)]; !ok {
+			first[key(chore)] = k
+		}
+	}
+	sort.SliceStable(chores, func(a, b int) bool {
+		if sa, sb := choreSize(chores[a], w), choreSize(chores[b], w); sa != sb {
+			return sa > sb
+		}
+		return first[key(chores[a])] < first[key(chores[b])]
+	})
+	return chores
+}
+
+func choreKey(chore models.Chore) string {
+	return fmt.Sprintf("%s\x00%d\x00%d", chore.Name, chore.Difficulty, chore.Earned)
+}
+
+// sameEligibility reports whether people i and j may take the same chores from k on.
+func (s *optimalSearch) sameEligibility(k, i, j int) bool {
+	for ; k < len(s.chores); k++ {
+		if s.eligible[k][i] != s.eligible[k][j] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *optimalSearch) hasHistory(i int) bool {
+	return s.opts.RotationPenalty != 0 && len(s.opts.RecentChores[s.people[i].Name]) > 0
+}
+
+func (s *optimalSearch) record(cost float64) {
+	if s.unassigned == s.bestUnassigned && cost > s.bestCost-scoreEpsilon {
+		return
+	}
+	s.bestUnassigned = s.unassigned
+	s.bestCost = cost
+	s.best = append(s.best[:0], s.choice...)
+	s.improved = true
+}
+
+// done reports whether the best possible solution has already been found.
+func (s *optimalSearch) done() bool {
+	return s.improved && s.bestUnassigned == 0 && s.bestCost < scoreEpsilon
+}
+
+func (s *optimalSearch) budgetExceeded() bool {
+	if s.budget.MaxNodes > 0 && s.nodes > s.budget.MaxNodes {
+		return true
+	}
+	return !s.deadline.IsZero() && s.nodes%1024 == 0 && time.Now().After(s.deadline)
+}
+
+func (s *optimalSearch) result() *models.DistributionResult {
+	result := &models.DistributionResult{
+		People:   clonePeople(s.people),
+		Strategy: "optimal",
+		Weights:  s.weights,
+	}
+	assigned := result.People
+
+	var unassigned []models.Chore
+	for k, chore := range s.chores {
+		i := s.best[k]
+		if i < 0 {
+			unassigned = append(unassigned, chore)
+			continue
+		}
+		s.opts.decide([]models.Chore{chore}, assigned, []int{i}, nil, "the optimal search found this evens out the totals best")
+		s.opts.assign(&assigned[i], chore)
+	}
+
+	for _, bundle := range s.opts.bundle(unassigned) {
+		result.Unassigned = append(result.Unassigned, newBundleUnassigned(bundle, assigned, s.opts)...)
+	}
+
+	result.Fairness = ComputeFairness(assigned)
+	return result
+}
@@ -0,0 +1,199 @@
+package distributor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+func TestDistributeOptimal_ClosesGreedyGap(t *testing.T) {
+	// Greedy gives the two $3 chores to different people and ends up $7 vs $5,
+	// while 3+3 vs 2+2+2 is a perfect split.
+	chores := []models.Chore{
+		{Name: "Kitchen", Difficulty: 3, Earned: 3},
+		{Name: "Bathroom", Difficulty: 3, Earned: 3},
+		{Name: "Living Room", Difficulty: 2, Earned: 2},
+		{Name: "Dining Room", Difficulty: 2, Earned: 2},
+		{Name: "Mud Room", Difficulty: 2, Earned: 2},
+	}
+
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+
+	result := DistributeOptimal(chores, people, NewRand(1), DefaultOptimalOptions)
+
+	if len(result.Unassigned) != 0 {
+		t.Errorf("Expected all chores assigned, got %d unassigned", len(result.Unassigned))
+	}
+	if result.Fairness.EarnedSpread != 0 {
+		t.Errorf("Expected a perfect split, got Alice=$%d, Bob=$%d",
+			result.People[0].TotalEarned, result.People[1].TotalEarned)
+	}
+}
+
+func TestDistributeOptimal_RespectsCapacity(t *testing.T) {
+	chores := []models.Chore{
+		{Name: "Kitchen", Difficulty: 6, Earned: 5},
+		{Name: "Bathroom", Difficulty: 5, Earned: 4},
+		{Name: "Living Room", Difficulty: 4, Earned: 3},
+		{Name: "Dining Room", Difficulty: 4, Earned: 3},
+		{Name: "Family Room", Difficulty: 3, Earned: 2},
+		{Name: "Mud Room", Difficulty: 3, Earned: 2},
+	}
+
+	people := []models.Person{
+		{Name: "Jeff", Chores: []models.Chore{}},
+		{Name: "John", Chores: []models.Chore{}},
+		{Name: "Kristen", EffortCapacity: 5, Chores: []models.Chore{}},
+		{Name: "Tommy", EffortCapacity: 3, Chores: []models.Chore{}},
+	}
+
+	for seed := uint64(1); seed <= 20; seed++ {
+		result := DistributeOptimal(chores, people, NewRand(seed), DefaultOptimalOptions)
+
+		total := 0
+		for _, person := range result.People {
+			total += len(person.Chores)
+			if person.EffortCapacity > 0 && person.TotalDifficulty > person.EffortCapacity {
+				t.Errorf("seed %d: %s exceeded capacity: %d > %d",
+					seed, person.Name, person.TotalDifficulty, person.EffortCapacity)
+			}
+		}
+		if total != len(chores) {
+			t.Errorf("seed %d: expected %d chores assigned, got %d", seed, len(chores), total)
+		}
+
+		greedy := Distribute(chores, people, NewRand(seed))
+		if result.Fairness.EarnedSpread > greedy.Fairness.EarnedSpread {
+			t.Errorf("seed %d: optimal spread $%d is worse than greedy $%d",
+				seed, result.Fairness.EarnedSpread, greedy.Fairness.EarnedSpread)
+		}
+	}
+}
+
+func TestDistributeOptimal_PreAssignedCountsTowardBalance(t *testing.T) {
+	chores := []models.Chore{
+		{Name: "Kitchen", Difficulty: 2, Earned: 2},
+		{Name: "Bathroom", Difficulty: 2, Earned: 2},
+	}
+
+	people := []models.Person{
+		{
+			Name:              "Alice",
+			PreAssignedChores: []models.Chore{{Name: "Bedroom", Difficulty: 4, Earned: 4}},
+			TotalDifficulty:   4,
+			TotalEarned:       4,
+			Chores:            []models.Chore{},
+		},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+
+	result := DistributeOptimal(chores, people, NewRand(1), DefaultOptimalOptions)
+
+	if len(result.People[1].Chores) != 2 {
+		t.Errorf("Expected Bob to get both chores to match Alice's pre-assigned $4, got %d",
+			len(result.People[1].Chores))
+	}
+}
+
+func TestDistributeOptimal_BudgetFallsBackToGreedy(t *testing.T) {
+	chores := []models.Chore{
+		{Name: "Kitchen", Difficulty: 3, Earned: 3},
+		{Name: "Bathroom", Difficulty: 3, Earned: 3},
+		{Name: "Living Room", Difficulty: 2, Earned: 2},
+		{Name: "Dining Room", Difficulty: 2, Earned: 2},
+		{Name: "Mud Room", Difficulty: 2, Earned: 2},
+	}
+
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+
+	opts := OptimalOptions{MaxNodes: 1, TimeLimit: time.Second}
+	result := DistributeOptimal(chores, people, NewRand(1), opts)
+	greedy := Distribute(chores, people, NewRand(1))
+
+	if result.Fairness.EarnedSpread != greedy.Fairness.EarnedSpread {
+		t.Errorf("Expected greedy spread $%d when the budget is exhausted, got $%d",
+			greedy.Fairness.EarnedSpread, result.Fairness.EarnedSpread)
+	}
+}
+
+func TestDistributeOptimal_ReportsUnassigned(t *testing.T) {
+	chores := []models.Chore{
+		{Name: "BigChore", Difficulty: 20, Earned: 10},
+		{Name: "SmallChore", Difficulty: 1, Earned: 1},
+	}
+
+	people := []models.Person{
+		{Name: "Alice", EffortCapacity: 10, Chores: []models.Chore{}},
+	}
+
+	result := DistributeOptimal(chores, people, NewRand(1), DefaultOptimalOptions)
+
+	if len(result.Unassigned) != 1 || result.Unassigned[0].Chore.Name != "BigChore" {
+		t.Fatalf("Expected BigChore to be unassigned, got %+v", result.Unassigned)
+	}
+	if len(result.People[0].Chores) != 1 {
+		t.Errorf("Expected Alice to get SmallChore, got %d chores", len(result.People[0].Chores))
+	}
+}
+
+func TestDistributeOptimal_LeavesOutOneToFitTwo(t *testing.T) {
+	// Greedy hands out the biggest chore first, which leaves no room for either of the
+	// others; leaving it out fits both
+	chores := []models.Chore{
+		{Name: "Garage", Difficulty: 5, Earned: 5},
+		{Name: "Dishes", Difficulty: 3, Earned: 2},
+		{Name: "Trash", Difficulty: 3, Earned: 2},
+	}
+	people := []models.Person{
+		{Name: "Alice", EffortCapacity: 6, Chores: []models.Chore{}},
+	}
+
+	if greedy := distributeGreedy(chores, people, NewRand(1), DefaultOptions()); len(greedy.Unassigned) != 2 {
+		t.Fatalf("Expected greedy to leave 2 chores unassigned, got %+v", greedy.Unassigned)
+	}
+
+	result := DistributeOptimal(chores, people, NewRand(1), DefaultOptimalOptions)
+	if len(result.Unassigned) != 1 || result.Unassigned[0].Chore.Name != "Garage" {
+		t.Fatalf("Expected only Garage unassigned, got %+v", result.Unassigned)
+	}
+	if len(result.People[0].Chores) != 2 {
+		t.Errorf("Expected Alice to get Dishes and Trash, got %+v", result.People[0].Chores)
+	}
+}
+
+func TestDistributeOptimal_IdenticalInstances(t *testing.T) {
+	// A chore done every day, plus two that throw greedy off balance. Without treating
+	// the daily instances as interchangeable the search would try every ordering of them.
+	var chores []models.Chore
+	for d := 0; d < 7; d++ {
+		chores = append(chores, models.Chore{Name: "Dishes", Difficulty: 1, Earned: 1,
+			Date: time.Date(2025, time.June, 2+d, 0, 0, 0, 0, time.UTC)})
+	}
+	chores = append(chores,
+		models.Chore{Name: "Garage", Difficulty: 5, Earned: 5},
+		models.Chore{Name: "Yard", Difficulty: 4, Earned: 4},
+	)
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}, EffortCapacity: 8},
+		{Name: "Carol", Chores: []models.Chore{}, EffortCapacity: 6},
+	}
+
+	s := newOptimalSearch(chores, people, NewRand(1), OptimalOptions{MaxNodes: 300}, DefaultOptions())
+	s.seedIncumbent(distributeGreedy(chores, people, NewRand(1), DefaultOptions()))
+	s.search(0)
+	if s.stopped {
+		t.Fatalf("Expected the search to finish within budget, stopped after %d nodes", s.nodes)
+	}
+	if s.bestUnassigned != 0 || s.bestCost != 1 {
+		t.Errorf("Expected everything assigned with a $1 spread, got %d unassigned and cost %v",
+			s.bestUnassigned, s.bestCost)
+	}
+}
@@ -0,0 +1,102 @@
+package distributor
+
+import (
+	"math"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// Options holds the settings shared by every strategy.
+type Options struct {
+	// Weights controls how earnings, difficulty and chore count are traded off
+	// when balancing people against each other.
+	Weights models.Weights
+
+	// RecentChores counts how many times each person (by name) had each chore (by name)
+	// in the rotation window. RotationPenalty is added to a person's score for a chore
+	// once per time they had it, so chores rotate instead of repeating week after week.
+	RecentChores    map[string]map[string]int
+	RotationPenalty float64
+
+	// Date is the day the distribution is for, used to work out ages from birthdates.
+	// Today is used if it is not set. It is also the first day of the weekly plan.
+	Date time.Time
+
+	// WeekPlan schedules every chore on a day of the week. Otherwise only chores that
+	// are dated or limited to certain Days are.
+	WeekPlan bool
+
+	// NeverPair lists groups of people (by name) who are never put on the same team
+	// chore.
+	NeverPair [][]string
+
+	// Together lists groups of chores (by name) that go to the same person: every
+	// instance in a group due on the same day, or not due on a particular day, goes to
+	// one person or, if no one can take them all, to nobody. Apart lists groups of
+	// chores no one person gets more than one of.
+	Together [][]string
+	Apart    [][]string
+
+	// Tolerance is how far apart, in earnings, the preference strategy may leave
+	// people to give them chores they like.
+	Tolerance int
+
+	// Trace, if set, collects an explanation of every decision made.
+	Trace *Trace
+}
+
+// DefaultOptions balances earnings only, which is the original behavior.
+func DefaultOptions() Options {
+	return Options{Weights: models.Weights{Earnings: 1}, Tolerance: DefaultTolerance}
+}
+
+// scoreEpsilon treats weighted scores this close together as a tie.
+const scoreEpsilon = 1e-9
+
+// balance is the earnings figure used for balancing: this week's earnings plus
+// whatever surplus or deficit was carried over from previous weeks.
+func balance(person models.Person) int {
+	return person.TotalEarned + person.CarryOver
+}
+
+// ScoreComponents breaks a person's weighted score into its earnings, difficulty and
+// chore-count parts. The score is their sum; lower scores are given chores first.
+// Earnings include any carry-over from previous weeks.
+func ScoreComponents(person models.Person, w models.Weights) (earnings, difficulty, chores float64) {
+	count := len(person.PreAssignedChores) + len(person.Chores)
+	return w.Earnings * float64(balance(person)),
+		w.Difficulty * float64(person.TotalDifficulty),
+		w.Chores * float64(count)
+}
+
+// Score is a person's weighted load: how much they already have on their plate.
+func Score(person models.Person, w models.Weights) float64 {
+	e, d, c := ScoreComponents(person, w)
+	return e + d + c
+}
+
+// repeats reports how many times the person had the chore in the rotation window.
+func (o Options) repeats(person models.Person, chore models.Chore) int {
+	return o.RecentChores[person.Name][chore.Name]
+}
+
+// rotationCost is the penalty for giving the person a chore they had recently.
+func (o Options) rotationCost(person models.Person, chore models.Chore) float64 {
+	return o.RotationPenalty * float64(o.repeats(person, chore))
+}
+
+// choreSize is how much a chore adds to the score of whoever takes it. Chores are
+// handed out largest first so the small ones can even things out at the end.
+func choreSize(chore models.Chore, w models.Weights) float64 {
+	return w.Earnings*float64(chore.Earned) + w.Difficulty*float64(chore.Difficulty) + w.Chores
+}
+
+// multiObjective reports whether anything besides earnings is being balanced.
+func multiObjective(w models.Weights) bool {
+	return w.Difficulty != 0 || w.Chores != 0
+}
+
+func sameScore(a, b float64) bool {
+	return math.Abs(a-b) < scoreEpsilon
+}
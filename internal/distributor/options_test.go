@@ -0,0 +1,222 @@
+package distributor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// sameEarningsChores all pay the same, so balancing earnings alone says nothing
+// about how the effort is split.
+func sameEarningsChores() []models.Chore {
+	return []models.Chore{
+		{Name: "Garage", Difficulty: 9, Earned: 3},
+		{Name: "Yard", Difficulty: 9, Earned: 3},
+		{Name: "Mail", Difficulty: 1, Earned: 3},
+		{Name: "Plants", Difficulty: 1, Earned: 3},
+	}
+}
+
+func TestScoreComponents(t *testing.T) {
+	person := models.Person{
+		PreAssignedChores: []models.Chore{{Name: "Bedroom"}},
+		Chores:            []models.Chore{{Name: "Kitchen"}, {Name: "Bathroom"}},
+		TotalEarned:       8,
+		TotalDifficulty:   10,
+	}
+
+	e, d, c := ScoreComponents(person, models.Weights{Earnings: 1, Difficulty: 0.5, Chores: 2})
+	if e != 8 || d != 5 || c != 6 {
+		t.Errorf("Expected components 8, 5, 6, got %v, %v, %v", e, d, c)
+	}
+	if Score(person, models.Weights{Earnings: 1, Difficulty: 0.5, Chores: 2}) != 19 {
+		t.Error("Score should be the sum of its components")
+	}
+}
+
+func TestGreedy_BalancesDifficultyWithWeights(t *testing.T) {
+	opts := Options{Weights: models.Weights{Earnings: 1, Difficulty: 1}}
+
+	for seed := uint64(1); seed <= 20; seed++ {
+		people := []models.Person{
+			{Name: "Alice", Chores: []models.Chore{}},
+			{Name: "Bob", Chores: []models.Chore{}},
+		}
+
+		result := Greedy{}.Distribute(sameEarningsChores(), people, NewRand(seed), opts)
+
+		if result.Fairness.EarnedSpread != 0 {
+			t.Errorf("seed %d: expected equal earnings, got spread $%d", seed, result.Fairness.EarnedSpread)
+		}
+		if result.Fairness.DifficultySpread != 0 {
+			t.Errorf("seed %d: expected equal difficulty, got %d vs %d", seed,
+				result.People[0].TotalDifficulty, result.People[1].TotalDifficulty)
+		}
+	}
+}
+
+func TestOptimal_BalancesDifficultyWithWeights(t *testing.T) {
+	opts := Options{Weights: models.Weights{Earnings: 1, Difficulty: 1}}
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+
+	result := Optimal{Budget: DefaultOptimalOptions}.Distribute(sameEarningsChores(), people, NewRand(1), opts)
+
+	if result.Fairness.EarnedSpread != 0 || result.Fairness.DifficultySpread != 0 {
+		t.Errorf("Expected earnings and difficulty both balanced, got %+v", result.Fairness)
+	}
+}
+
+func TestGreedy_BalancesChoreCountWithWeights(t *testing.T) {
+	chores := []models.Chore{
+		{Name: "Kitchen", Difficulty: 6, Earned: 6},
+		{Name: "Mail", Difficulty: 1, Earned: 2},
+		{Name: "Plants", Difficulty: 1, Earned: 2},
+		{Name: "Trash", Difficulty: 1, Earned: 2},
+	}
+	opts := Options{Weights: models.Weights{Chores: 1}}
+
+	for seed := uint64(1); seed <= 20; seed++ {
+		people := []models.Person{
+			{Name: "Alice", Chores: []models.Chore{}},
+			{Name: "Bob", Chores: []models.Chore{}},
+		}
+
+		result := Greedy{}.Distribute(chores, people, NewRand(seed), opts)
+
+		if len(result.People[0].Chores) != 2 || len(result.People[1].Chores) != 2 {
+			t.Errorf("seed %d: expected 2 chores each, got %d and %d", seed,
+				len(result.People[0].Chores), len(result.People[1].Chores))
+		}
+	}
+}
+
+func TestPrintDistribution_ScoreComponents(t *testing.T) {
+	result := &models.DistributionResult{
+		People: []models.Person{
+			{
+				Name:            "Alice",
+				Chores:          []models.Chore{{Name: "Kitchen", Difficulty: 6, Earned: 5}},
+				TotalDifficulty: 6,
+				TotalEarned:     5,
+			},
+		},
+		Weights: models.Weights{Earnings: 1, Difficulty: 0.5},
+	}
+
+	var buf bytes.Buffer
+	PrintDistribution(&buf, result, PrintOptions{Verbose: true})
+	output := buf.String()
+
+	if !strings.Contains(output, "Score: 8.00 (earnings 5.00 + difficulty 3.00 + chores 0.00)") {
+		t.Errorf("Verbose output should show score components, got:\n%s", output)
+	}
+
+	buf.Reset()
+	PrintDistribution(&buf, result, PrintOptions{Verbose: false})
+	if strings.Contains(buf.String(), "Score:") {
+		t.Error("Default output should not show score components")
+	}
+
+	buf.Reset()
+	result.Weights = models.Weights{Earnings: 1}
+	PrintDistribution(&buf, result, PrintOptions{Verbose: true})
+	if strings.Contains(buf.String(), "Score:") {
+		t.Error("Score components should only be shown when balancing more than earnings")
+	}
+}
+
+func TestStrategies_RotationAvoidsRepeats(t *testing.T) {
+	chores := []models.Chore{{Name: "Bathroom", Difficulty: 5, Earned: 4}}
+	opts := DefaultOptions()
+	opts.RecentChores = map[string]map[string]int{"Alice": {"Bathroom": 2}}
+	opts.RotationPenalty = 1
+
+	for _, name := range Strategies() {
+		strategy, _ := Lookup(name)
+		for seed := uint64(1); seed <= 20; seed++ {
+			people := []models.Person{
+				{Name: "Alice", Chores: []models.Chore{}},
+				{Name: "Bob", Chores: []models.Chore{}},
+			}
+
+			result := strategy.Distribute(chores, people, NewRand(seed), opts)
+			if len(result.People[0].Chores) != 0 {
+				t.Errorf("%s seed %d: Alice got Bathroom again despite having it the last 2 weeks", name, seed)
+			}
+		}
+	}
+}
+
+func TestOptimal_RotationKeepsEarningsBalanced(t *testing.T) {
+	chores := []models.Chore{
+		{Name: "Bathroom", Difficulty: 5, Earned: 3},
+		{Name: "Kitchen", Difficulty: 5, Earned: 3},
+	}
+	opts := DefaultOptions()
+	opts.RecentChores = map[string]map[string]int{"Alice": {"Bathroom": 1}}
+	opts.RotationPenalty = 2
+
+	for seed := uint64(1); seed <= 20; seed++ {
+		people := []models.Person{
+			{Name: "Alice", Chores: []models.Chore{}},
+			{Name: "Bob", Chores: []models.Chore{}},
+		}
+
+		result := Optimal{Budget: DefaultOptimalOptions}.Distribute(chores, people, NewRand(seed), opts)
+
+		if len(result.People[0].Chores) != 1 || result.People[0].Chores[0].Name != "Kitchen" {
+			t.Errorf("seed %d: expected Alice to get only Kitchen, got %+v", seed, result.People[0].Chores)
+		}
+		if result.Fairness.EarnedSpread != 0 {
+			t.Errorf("seed %d: expected balanced earnings, got spread $%d", seed, result.Fairness.EarnedSpread)
+		}
+	}
+}
+
+func TestStrategies_CarryOverFavorsPersonBehind(t *testing.T) {
+	chores := []models.Chore{{Name: "Kitchen", Difficulty: 6, Earned: 5}}
+
+	for _, name := range []string{"greedy", "optimal"} {
+		strategy, _ := Lookup(name)
+		for seed := uint64(1); seed <= 20; seed++ {
+			people := []models.Person{
+				{Name: "Jeff", CarryOver: 3, Chores: []models.Chore{}},
+				{Name: "John", CarryOver: -3, Chores: []models.Chore{}},
+			}
+
+			result := strategy.Distribute(chores, people, NewRand(seed), DefaultOptions())
+			if len(result.People[1].Chores) != 1 {
+				t.Errorf("%s seed %d: expected John, $3 behind, to get Kitchen", name, seed)
+			}
+			if result.People[1].TotalEarned != 5 {
+				t.Errorf("%s seed %d: carry-over should not be added to TotalEarned, got $%d",
+					name, seed, result.People[1].TotalEarned)
+			}
+		}
+	}
+}
+
+func TestPrintDistribution_CarryOver(t *testing.T) {
+	result := &models.DistributionResult{
+		People: []models.Person{
+			{Name: "Jeff", CarryOver: -3, TotalEarned: 5},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintDistribution(&buf, result, PrintOptions{Verbose: true})
+	if !strings.Contains(buf.String(), "Carried Over: -$3 from previous weeks") {
+		t.Errorf("Verbose output should show carry-over, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	PrintDistribution(&buf, result, PrintOptions{Verbose: false})
+	if strings.Contains(buf.String(), "Carried Over") {
+		t.Error("Default output should not show carry-over")
+	}
+}
@@ -0,0 +1,191 @@
+package distributor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// Pin keeps a chore with particular people, so only the rest are handed out.
+type Pin struct {
+	// Chore is the chore's name. If Date is set, only the instance due that day is
+	// pinned; otherwise every instance is.
+	Chore string
+	Date  time.Time
+	// People is who gets it: one person, or everyone on a team chore, in the order
+	// they take the shares, largest first.
+	People []string
+}
+
+// String describes the pin, such as "Kitchen → John" or "Garage → John + Mary".
+func (p Pin) String() string {
+	return models.Chore{Name: p.Chore, Date: p.Date}.Label() + " → " + strings.Join(p.People, " + ")
+}
+
+// ParsePins parses pins given as CHORE=PERSON, or CHORE=PERSON+PERSON for a team
+// chore. Pinning the same chore twice is an error.
+func ParsePins(args []string) ([]Pin, error) {
+	var pins []Pin
+	for _, arg := range args {
+		chore, people, ok := strings.Cut(arg, "=")
+		chore = strings.TrimSpace(chore)
+		if !ok || chore == "" || strings.TrimSpace(people) == "" {
+			return nil, fmt.Errorf("invalid pin %q (want CHORE=PERSON)", arg)
+		}
+		if slices.ContainsFunc(pins, func(p Pin) bool { return strings.EqualFold(p.Chore, chore) }) {
+			return nil, fmt.Errorf("%s is pinned more than once; pin a team chore to everyone at once, as %s=PERSON+PERSON", chore, chore)
+		}
+		pin := Pin{Chore: chore}
+		for _, name := range strings.Split(people, "+") {
+			pin.People = append(pin.People, strings.TrimSpace(name))
+		}
+		pins = append(pins, pin)
+	}
+	return pins, nil
+}
+
+// matches reports whether the pin is for the chore.
+func (p Pin) matches(chore models.Chore) bool {
+	return strings.EqualFold(p.Chore, chore.Name) && (p.Date.IsZero() || p.Date.Equal(chore.Date))
+}
+
+// PinChores gives each pinned chore to its people before anything else is handed
+// out, and returns the chores left for a strategy to hand out and copies of the people
+// holding the pinned ones. Chores kept together with a pinned chore go along with it.
+// A pin that names no chore this week, someone who isn't around, or a chore they
+// can't take is an error.
+func PinChores(chores []models.Chore, people []models.Person, pins []Pin, opts Options) ([]models.Chore, []models.Person, error) {
+	people = clonePeople(people)
+	taken := make([]bool, len(chores))
+
+	for _, pin := range pins {
+		var members []int
+		for _, name := range pin.People {
+			i := slices.IndexFunc(people, func(p models.Person) bool { return strings.EqualFold(p.Name, name) })
+			if i < 0 {
+				return nil, nil, fmt.Errorf("can't pin %s: %s isn't around this week", pin, name)
+			}
+			members = append(members, i)
+		}
+
+		found, already := false, false
+		for k, chore := range chores {
+			if !pin.matches(chore) {
+				continue
+			}
+			if taken[k] {
+				already = true
+				continue
+			}
+			found = true
+			taken[k] = true
+
+			if n := TeamSize(chore); n != len(members) {
+				return nil, nil, fmt.Errorf("can't pin %s: %s takes %d people", pin, chore.Name, n)
+			}
+			if len(members) > 1 {
+				if err := opts.pinTeam(chore, people, members); err != nil {
+					return nil, nil, fmt.Errorf("can't pin %s: %w", pin, err)
+				}
+				continue
+			}
+
+			unit := []models.Chore{chore}
+			if key := opts.togetherKey(chore); key != "" {
+				for j, other := range chores {
+					if !taken[j] && opts.togetherKey(other) == key {
+						taken[j] = true
+						unit = append(unit, other)
+					}
+				}
+			}
+			i := members[0]
+			if reason := opts.whyNotAll(people[i], unit); reason != "" {
+				return nil, nil, fmt.Errorf("can't pin %s: %s", pin, reason)
+			}
+			opts.decide(unit, people, members, nil, "pinned")
+			for _, c := range unit {
+				opts.assign(&people[i], c)
+			}
+		}
+		if !found && already {
+			return nil, nil, fmt.Errorf("can't pin %s: it's already pinned", pin)
+		}
+		if !found {
+			return nil, nil, fmt.Errorf("can't pin %s: there's no such chore this week", pin)
+		}
+	}
+
+	var rest []models.Chore
+	for k, chore := range chores {
+		if !taken[k] {
+			rest = append(rest, chore)
+		}
+	}
+	return rest, people, nil
+}
+
+// pinTeam gives a team chore to the people at members, the first taking the largest
+// share, on a day they can all do it.
+func (o Options) pinTeam(chore models.Chore, people []models.Person, members []int) error {
+	parts := Shares(chore)
+	for k, i := range members {
+		if reason := o.whyNot(people[i], parts[k]); reason != "" {
+			return fmt.Errorf("%s: %s", people[i].Name, reason)
+		}
+	}
+	day, ok := o.pickTeamDay(chore, parts, people, members)
+	if !ok {
+		return fmt.Errorf("no day they all have room for it")
+	}
+
+	o.decide(parts[len(parts)-1:], people, members, nil, "pinned")
+	var names []string
+	for _, i := range members {
+		names = append(names, people[i].Name)
+	}
+	for k, i := range members {
+		part := parts[k]
+		part.Team = names
+		if day != noDay {
+			part.Date = o.weekStart().AddDate(0, 0, day)
+		}
+		o.assign(&people[i], part)
+	}
+	return nil
+}
+
+// PinsFor pins the chore, by name, to whoever has it in the result: a team chore to
+// the whole team, and each instance of a recurring chore to whoever has that instance.
+// chores are the chores the result was distributed from. It returns nil if nobody has
+// the chore.
+func PinsFor(result *models.DistributionResult, chores []models.Chore, name string) []Pin {
+	var pins []Pin
+	for _, person := range result.People {
+		for _, c := range person.Chores {
+			if !strings.EqualFold(c.Name, name) {
+				continue
+			}
+			pin := Pin{Chore: c.Name, People: []string{person.Name}}
+			if len(c.Team) > 0 {
+				pin.People = c.Team
+			}
+			// Only instances due on a particular day are told apart by their date; the day
+			// any other chore is scheduled on is picked again
+			if slices.ContainsFunc(chores, func(d models.Chore) bool {
+				return d.Name == c.Name && !d.Date.IsZero() && d.Date.Equal(c.Date)
+			}) {
+				pin.Date = c.Date
+			}
+			if !slices.ContainsFunc(pins, func(p Pin) bool {
+				return p.Chore == pin.Chore && p.Date.Equal(pin.Date) && slices.Equal(p.People, pin.People)
+			}) {
+				pins = append(pins, pin)
+			}
+		}
+	}
+	return pins
+}
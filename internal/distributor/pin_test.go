@@ -0,0 +1,176 @@
+package distributor
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+func TestPinChores(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+	chores := []models.Chore{
+		{Name: "Kitchen", Earned: 5, Difficulty: 6},
+		{Name: "Load Dishwasher", Earned: 2, Difficulty: 2},
+		{Name: "Unload Dishwasher", Earned: 2, Difficulty: 2},
+		{Name: "Trash", Earned: 1, Difficulty: 1},
+	}
+	opts := DefaultOptions()
+	opts.Together = [][]string{{"Load Dishwasher", "Unload Dishwasher"}}
+	trace := &Trace{}
+	opts.Trace = trace
+
+	// Pins are matched regardless of case, and Unload Dishwasher goes along with Load
+	pins := []Pin{
+		{Chore: "kitchen", People: []string{"bob"}},
+		{Chore: "Load Dishwasher", People: []string{"Alice"}},
+	}
+	rest, pinned, err := PinChores(chores, people, pins, opts)
+	if err != nil {
+		t.Fatalf("PinChores failed: %v", err)
+	}
+
+	if len(rest) != 1 || rest[0].Name != "Trash" {
+		t.Errorf("Expected only Trash left, got %+v", rest)
+	}
+	if len(pinned[1].Chores) != 1 || pinned[1].Chores[0].Name != "Kitchen" || pinned[1].TotalEarned != 5 {
+		t.Errorf("Expected Bob to have Kitchen, got %+v", pinned[1])
+	}
+	if len(pinned[0].Chores) != 2 || pinned[0].TotalEarned != 4 {
+		t.Errorf("Expected Alice to have both dishwasher chores, got %+v", pinned[0])
+	}
+	if len(people[0].Chores) != 0 || len(people[1].Chores) != 0 {
+		t.Error("Expected the people passed in to be left alone")
+	}
+	if len(trace.Decisions) != 2 || trace.Decisions[0].Rule != "pinned" {
+		t.Errorf("Expected the pins to be recorded as decisions, got %+v", trace.Decisions)
+	}
+
+	// The rest are distributed around the pins
+	result := distributeGreedy(rest, pinned, NewRand(1), DefaultOptions())
+	if got := holders(result, "Kitchen"); len(got) != 1 || got[0] != "Bob" {
+		t.Errorf("Expected Bob to keep Kitchen, got %v", got)
+	}
+	if got := holders(result, "Trash"); len(got) != 1 || got[0] != "Alice" {
+		t.Errorf("Expected Trash to even things out to Alice, got %v", got)
+	}
+}
+
+func TestPinChores_Team(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}},
+		{Name: "Carol", Chores: []models.Chore{}},
+	}
+	chores := []models.Chore{{Name: "Garage", Earned: 5, Difficulty: 4, Headcount: 2}}
+
+	_, pinned, err := PinChores(chores, people, []Pin{{Chore: "Garage", People: []string{"Carol", "Alice"}}}, DefaultOptions())
+	if err != nil {
+		t.Fatalf("PinChores failed: %v", err)
+	}
+	if pinned[2].TotalEarned != 3 || pinned[0].TotalEarned != 2 || len(pinned[1].Chores) != 0 {
+		t.Errorf("Expected Carol to take the larger share and Alice the other, got %+v", pinned)
+	}
+	if team := pinned[0].Chores[0].Team; !slices.Equal(team, []string{"Carol", "Alice"}) {
+		t.Errorf("Expected the team recorded, got %v", team)
+	}
+}
+
+func TestPinChores_Errors(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}, EffortCapacity: 3},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+	chores := []models.Chore{
+		{Name: "Kitchen", Earned: 5, Difficulty: 6},
+		{Name: "Garage", Earned: 4, Difficulty: 4, Headcount: 2},
+	}
+
+	tests := []struct {
+		pin  Pin
+		want string
+	}{
+		{Pin{Chore: "Kitchen", People: []string{"Carol"}}, "Carol isn't around this week"},
+		{Pin{Chore: "Mow", People: []string{"Bob"}}, "there's no such chore this week"},
+		{Pin{Chore: "Kitchen", People: []string{"Alice"}}, "effort capacity"},
+		{Pin{Chore: "Garage", People: []string{"Bob"}}, "Garage takes 2 people"},
+	}
+	for _, tt := range tests {
+		_, _, err := PinChores(chores, people, []Pin{tt.pin}, DefaultOptions())
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Expected pinning %s to fail with %q, got %v", tt.pin, tt.want, err)
+		}
+	}
+}
+
+func TestPinChores_Twice(t *testing.T) {
+	people := []models.Person{{Name: "Alice", Chores: []models.Chore{}}, {Name: "Bob", Chores: []models.Chore{}}}
+	chores := []models.Chore{{Name: "Kitchen", Earned: 5, Difficulty: 6}}
+
+	pins := []Pin{{Chore: "Kitchen", People: []string{"Alice"}}, {Chore: "kitchen", People: []string{"Bob"}}}
+	if _, _, err := PinChores(chores, people, pins, DefaultOptions()); err == nil || !strings.Contains(err.Error(), "already pinned") {
+		t.Errorf("Expected pinning Kitchen twice to fail as already pinned, got %v", err)
+	}
+}
+
+func TestParsePins(t *testing.T) {
+	pins, err := ParsePins([]string{"Kitchen=John", " Garage = John + Mary "})
+	if err != nil {
+		t.Fatalf("ParsePins failed: %v", err)
+	}
+	if len(pins) != 2 || pins[0].String() != "Kitchen → John" || pins[1].String() != "Garage → John + Mary" {
+		t.Errorf("Unexpected pins: %v", pins)
+	}
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"Kitchen"}, "want CHORE=PERSON"},
+		{[]string{"=John"}, "want CHORE=PERSON"},
+		{[]string{"Kitchen=John", "kitchen=Mary"}, "kitchen is pinned more than once"},
+	}
+	for _, tt := range tests {
+		if _, err := ParsePins(tt.args); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Expected %q to fail with %q, got %v", tt.args, tt.want, err)
+		}
+	}
+}
+
+func TestPinsFor(t *testing.T) {
+	monday := time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	chores := []models.Chore{
+		{Name: "Dishes", Date: monday},
+		{Name: "Dishes", Date: tuesday},
+		{Name: "Kitchen"},
+		{Name: "Garage", Headcount: 2},
+	}
+	garage := models.Chore{Name: "Garage", Team: []string{"Bob", "Alice"}}
+	result := &models.DistributionResult{
+		People: []models.Person{
+			{Name: "Alice", Chores: []models.Chore{{Name: "Dishes", Date: monday}, garage}},
+			// Kitchen was scheduled on a day, but could be done any day
+			{Name: "Bob", Chores: []models.Chore{{Name: "Dishes", Date: tuesday}, {Name: "Kitchen", Date: tuesday}, garage}},
+		},
+	}
+
+	dishes := PinsFor(result, chores, "dishes")
+	if len(dishes) != 2 || dishes[0].String() != "Dishes (Mon Jun 2) → Alice" || dishes[1].String() != "Dishes (Tue Jun 3) → Bob" {
+		t.Errorf("Expected each instance pinned to its holder, got %v", dishes)
+	}
+	if kitchen := PinsFor(result, chores, "Kitchen"); len(kitchen) != 1 || !kitchen[0].Date.IsZero() {
+		t.Errorf("Expected Kitchen pinned on any day, got %v", kitchen)
+	}
+	if team := PinsFor(result, chores, "Garage"); len(team) != 1 || team[0].String() != "Garage → Bob + Alice" {
+		t.Errorf("Expected Garage pinned to the whole team once, got %v", team)
+	}
+	if none := PinsFor(result, chores, "Mow"); none != nil {
+		t.Errorf("Expected no pins for a chore nobody has, got %v", none)
+	}
+}
@@ -0,0 +1,176 @@
+package distributor
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// PlanRotationPenalty is the rotation penalty used for chores repeated within a
+// multi-week plan when the config doesn't set one.
+const PlanRotationPenalty = 2
+
+// PlannedWeek is one week of a multi-week plan.
+type PlannedWeek struct {
+	Start  time.Time
+	Result *models.DistributionResult
+}
+
+// Week is what there is to distribute in a week: the chores due, the people around and
+// away, the options to distribute with, and any surge pricing.
+type Week struct {
+	Chores  []models.Chore
+	Present []models.Person
+	Away    []models.Person
+	Opts    Options
+	Pricing []models.PriceChange
+}
+
+// Plan distributes weeks weeks in turn with the strategy, the first starting on start
+// and each of the rest a week after the one before. prepare returns the week starting
+// on weekStart, given the weeks planned before it, so it can count them as history:
+// carrying earnings over and rotating chores from one week to the next. The first week
+// uses seed, so it is the same as a single run with that seed, and the rest use seeds
+// drawn from it.
+func Plan(strategy Strategy, start time.Time, weeks int, seed uint64, prepare func(weekStart time.Time, planned []PlannedWeek) Week) []PlannedWeek {
+	rng := NewRand(seed)
+
+	var planned []PlannedWeek
+	weekSeed := seed
+	for k := range weeks {
+		weekStart := start.AddDate(0, 0, 7*k)
+		week := prepare(weekStart, planned)
+		trace := &Trace{}
+		week.Opts.Trace = trace
+
+		result := strategy.Distribute(week.Chores, week.Present, NewRand(weekSeed), week.Opts)
+		result.Seed = weekSeed
+		result.Away = week.Away
+		result.Pricing = week.Pricing
+		result.Decisions = trace.Decisions
+
+		planned = append(planned, PlannedWeek{Start: weekStart, Result: result})
+		weekSeed = rng.Uint64()
+	}
+	return planned
+}
+
+// PrintPlan writes a multi-week plan as a grid of who does each chore each week,
+// followed by everyone's earnings each week and in total.
+func PrintPlan(w io.Writer, weeks []PlannedWeek) {
+	fmt.Fprintf(w, "\n=== %d-Week Plan ===\n\n", len(weeks))
+
+	var chores, people []string
+	for _, week := range weeks {
+		for _, person := range week.Result.People {
+			if !slices.Contains(people, person.Name) {
+				people = append(people, person.Name)
+			}
+			for _, chore := range person.Chores {
+				if !slices.Contains(chores, chore.Name) {
+					chores = append(chores, chore.Name)
+				}
+			}
+		}
+		for _, u := range week.Result.Unassigned {
+			if !slices.Contains(chores, u.Chore.Name) {
+				chores = append(chores, u.Chore.Name)
+			}
+		}
+	}
+	slices.Sort(chores)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := []string{"Chore"}
+	for _, week := range weeks {
+		header = append(header, week.Start.Format("Jan 2"))
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, chore := range chores {
+		row := []string{chore}
+		for _, week := range weeks {
+			row = append(row, planCell(week.Result, chore))
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	fmt.Fprintln(tw)
+
+	earnedHeader := append([]string{"Earned"}, header[1:]...)
+	fmt.Fprintln(tw, strings.Join(append(earnedHeader, "Total"), "\t"))
+	totals := make(map[string]int)
+	for _, name := range people {
+		row := []string{name}
+		for _, week := range weeks {
+			k := slices.IndexFunc(week.Result.People, func(p models.Person) bool { return p.Name == name })
+			if k < 0 {
+				row = append(row, "away")
+				continue
+			}
+			earned := week.Result.People[k].TotalEarned
+			totals[name] += earned
+			row = append(row, fmt.Sprintf("$%d", earned))
+		}
+		row = append(row, fmt.Sprintf("$%d", totals[name]))
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+
+	if len(people) > 0 {
+		least, most := totals[people[0]], totals[people[0]]
+		for _, name := range people {
+			least, most = min(least, totals[name]), max(most, totals[name])
+		}
+		fmt.Fprintf(w, "\nOver %d weeks: $%d to $%d (gap $%d)\n", len(weeks), least, most, most-least)
+	}
+}
+
+// planCell lists who does the chore in a week, such as "Alice", "Alice ×2, Bob" for
+// a chore due more than once, or "Alice + Bob" for a team chore. It is blank if the
+// chore isn't due that week.
+func planCell(result *models.DistributionResult, chore string) string {
+	var parts []string
+	for _, person := range result.People {
+		n := 0
+		team := ""
+		for _, c := range person.Chores {
+			if c.Name == chore {
+				n++
+				team = strings.Join(c.Team, " + ")
+			}
+		}
+		switch {
+		case n == 0:
+		case team != "" && n == 1:
+			// Everyone on the team has the chore, so list the team once
+			if !slices.Contains(parts, team) {
+				parts = append(parts, team)
+			}
+		default:
+			parts = append(parts, countedName(person.Name, n))
+		}
+	}
+
+	unassigned := 0
+	for _, u := range result.Unassigned {
+		if u.Chore.Name == chore {
+			unassigned++
+		}
+	}
+	if unassigned > 0 {
+		parts = append(parts, countedName("unassigned", unassigned))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// countedName is the name, followed by how many times if more than once.
+func countedName(name string, n int) string {
+	if n == 1 {
+		return name
+	}
+	return fmt.Sprintf("%s ×%d", name, n)
+}
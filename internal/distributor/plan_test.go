@@ -0,0 +1,123 @@
+package distributor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/history"
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+func TestPlan(t *testing.T) {
+	start := time.Date(2025, time.June, 1, 9, 0, 0, 0, time.UTC)
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+	chores := []models.Chore{
+		{Name: "Kitchen", Earned: 5, Difficulty: 5},
+		{Name: "Trash", Earned: 2, Difficulty: 2},
+	}
+
+	// Count the weeks planned so far as history, as the distribute command does
+	var starts []time.Time
+	prepare := func(weekStart time.Time, planned []PlannedWeek) Week {
+		starts = append(starts, weekStart)
+		var entries []history.Entry
+		for _, week := range planned {
+			entries = append(entries, history.NewEntry(week.Result, "", week.Start))
+		}
+		opts := DefaultOptions()
+		opts.Date = weekStart
+		opts.RecentChores = history.RecentChores(entries, weekStart, len(planned))
+		opts.RotationPenalty = PlanRotationPenalty
+		carry := history.CarryOver(entries, weekStart, len(planned))
+		present := clonePeople(people)
+		for i := range present {
+			present[i].CarryOver = carry[present[i].Name]
+		}
+		return Week{Chores: chores, Present: present, Opts: opts}
+	}
+
+	weeks := Plan(Greedy{}, start, 2, 42, prepare)
+	if len(weeks) != 2 || !starts[1].Equal(start.AddDate(0, 0, 7)) || !weeks[1].Start.Equal(starts[1]) {
+		t.Fatalf("Expected 2 weeks a week apart, got %+v", weeks)
+	}
+
+	// The first week is the same as a single run with the seed, and the second uses a
+	// seed drawn from it
+	single := Greedy{}.Distribute(chores, people, NewRand(42), DefaultOptions())
+	if got, want := holders(weeks[0].Result, "Kitchen"), holders(single, "Kitchen"); got[0] != want[0] {
+		t.Errorf("Expected week 1 to match a single run, got Kitchen to %v instead of %v", got, want)
+	}
+	if weeks[0].Result.Seed != 42 || weeks[1].Result.Seed != NewRand(42).Uint64() {
+		t.Errorf("Unexpected seeds: %d, %d", weeks[0].Result.Seed, weeks[1].Result.Seed)
+	}
+
+	// Whoever had Trash in week 1 starts week 2 behind, and whoever had Kitchen would
+	// be repeating it, so they swap
+	first := holders(weeks[0].Result, "Kitchen")[0]
+	if second := holders(weeks[1].Result, "Kitchen")[0]; second == first {
+		t.Errorf("Expected Kitchen to move on from %s in week 2", first)
+	}
+	decisions := weeks[1].Result.Decisions
+	if len(decisions) == 0 {
+		t.Fatal("Expected week 2's decisions to be traced")
+	}
+	for _, c := range decisions[0].Considered {
+		want := -2
+		if c.Person == first {
+			want = 2
+		}
+		if c.CarryOver != want {
+			t.Errorf("Expected %s to carry over %d into week 2, got %d", c.Person, want, c.CarryOver)
+		}
+	}
+}
+
+func TestPrintPlan(t *testing.T) {
+	start := time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+	garage := models.Chore{Name: "Garage", Earned: 2, Team: []string{"Alice", "Bob"}}
+	dishes := models.Chore{Name: "Dishes", Earned: 1}
+	weeks := []PlannedWeek{
+		{
+			Start: start,
+			Result: &models.DistributionResult{
+				People: []models.Person{
+					{Name: "Alice", Chores: []models.Chore{dishes, dishes, garage}, TotalEarned: 4},
+					{Name: "Bob", Chores: []models.Chore{dishes, garage}, TotalEarned: 3},
+				},
+			},
+		},
+		{
+			Start: start.AddDate(0, 0, 7),
+			Result: &models.DistributionResult{
+				People: []models.Person{
+					{Name: "Bob", Chores: []models.Chore{dishes, dishes}, TotalEarned: 2},
+				},
+				Away:       []models.Person{{Name: "Alice", Away: "on a trip"}},
+				Unassigned: []models.UnassignedChore{{Chore: dishes, Reason: "no one has capacity"}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintPlan(&buf, weeks)
+	output := buf.String()
+
+	for _, want := range []string{
+		"=== 2-Week Plan ===",
+		"Chore   Jun 2          Jun 9\n",
+		"Dishes  Alice ×2, Bob  Bob ×2, unassigned\n",
+		"Garage  Alice + Bob",
+		"Alice   $4     away   $4\n",
+		"Bob     $3     $2     $5\n",
+		"Over 2 weeks: $4 to $5 (gap $1)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
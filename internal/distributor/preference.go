@@ -0,0 +1,302 @@
+package distributor
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// DefaultTolerance is how far apart in earnings the preference strategy may leave
+// people when the config does not say.
+const DefaultTolerance = 2
+
+// maxPreferencePasses bounds how many times the preference strategy goes over every
+// chore looking for a better home for it.
+const maxPreferencePasses = 50
+
+// Rating is how much the person likes the chore: 1 if they love it, -1 if they hate
+// it, and 0 if it is ok or they haven't rated it.
+func Rating(person models.Person, chore models.Chore) int {
+	switch person.Preferences[chore.Name] {
+	case models.Love:
+		return 1
+	case models.Hate:
+		return -1
+	}
+	return 0
+}
+
+// Satisfaction adds up the person's ratings of their chores, pre-assigned ones
+// included, and counts how many of them they love and hate.
+func Satisfaction(person models.Person) (total, loved, hated int) {
+	for _, chores := range [][]models.Chore{person.PreAssignedChores, person.Chores} {
+		for _, chore := range chores {
+			switch Rating(person, chore) {
+			case 1:
+				loved++
+			case -1:
+				hated++
+			}
+		}
+	}
+	return loved - hated, loved, hated
+}
+
+// Preference gives people the chores they love and keeps them away from the ones they
+// hate, as long as the gap between the highest and lowest earnings (including any
+// carry-over) stays within Options.Tolerance. It starts from a greedy distribution
+// and keeps moving or swapping chores between people while that raises the total
+// satisfaction, or brings the earnings gap back within the tolerance, or narrows it
+// without costing any satisfaction. Weights and rotation only affect the starting
+// distribution. Team chores are handed out first, the same way as by the greedy
+// strategy, and chores kept together are moved as one.
+type Preference struct{}
+
+func (Preference) Name() string { return "preference" }
+
+func (Preference) Distribute(chores []models.Chore, people []models.Person, rng *rand.Rand, opts Options) *models.DistributionResult {
+	people = clonePeople(people)
+	chores, teamUnassigned := opts.assignTeams(chores, people, rng)
+
+	s := &preferenceSearch{
+		base:  people,
+		units: opts.bundle(sortChores(chores, rng, opts.Weights)),
+		opts:  opts,
+	}
+	s.start(rng)
+	s.improve()
+
+	result := s.result()
+	result.Unassigned = append(teamUnassigned, result.Unassigned...)
+	return result
+}
+
+func init() {
+	Register(Preference{})
+}
+
+// preferenceSearch is a distribution being improved by the preference strategy.
+type preferenceSearch struct {
+	base  []models.Person  // the people before any of the units are handed out
+	units [][]models.Chore // chores that go to one person together, largest first
+	owner []int            // owner[u] is the person unit u goes to, -1 if nobody
+	opts  Options
+}
+
+// objective is what the preference strategy tries to improve, in order: fewer chores
+// unassigned, then less of the earnings gap beyond the tolerance, then more
+// satisfaction, then a smaller earnings gap.
+type objective struct {
+	unassigned   int
+	excess       int
+	satisfaction int
+	gap          int
+}
+
+func (a objective) better(b objective) bool {
+	switch {
+	case a.unassigned != b.unassigned:
+		return a.unassigned < b.unassigned
+	case a.excess != b.excess:
+		return a.excess < b.excess
+	case a.satisfaction != b.satisfaction:
+		return a.satisfaction > b.satisfaction
+	}
+	return a.gap < b.gap
+}
+
+// start hands out the units like the greedy strategy does, breaking ties in favor of
+// whoever likes the chores most, then at random.
+func (s *preferenceSearch) start(rng *rand.Rand) {
+	s.owner = make([]int, len(s.units))
+	assigned := clonePeople(s.base)
+	for u, unit := range s.units {
+		s.owner[u] = -1
+
+		var candidates []int
+		minScore, maxRating := math.Inf(1), math.MinInt
+		for i := range assigned {
+			if !s.opts.canTakeAll(assigned[i], unit) {
+				continue
+			}
+			score := Score(assigned[i], s.opts.Weights) + s.opts.bundleCost(assigned[i], unit)
+			rating := unitRating(assigned[i], unit)
+			switch {
+			case sameScore(score, minScore) && rating == maxRating:
+				candidates = append(candidates, i)
+			case sameScore(score, minScore) && rating > maxRating, !sameScore(score, minScore) && score < minScore:
+				minScore, maxRating = score, rating
+				candidates = []int{i}
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+
+		i := candidates[rng.IntN(len(candidates))]
+		for _, chore := range unit {
+			s.opts.assign(&assigned[i], chore)
+		}
+		s.owner[u] = i
+	}
+}
+
+// improve moves single units to other people, and swaps pairs of units between
+// people, for as long as that makes the distribution better.
+func (s *preferenceSearch) improve() {
+	current := s.evaluate()
+	for pass := 0; pass < maxPreferencePasses; pass++ {
+		improved := false
+
+		for u := range s.units {
+			for to := range s.base {
+				from := s.owner[u]
+				if to == from {
+					continue
+				}
+				s.owner[u] = to
+				if next := s.evaluate(); next.better(current) && s.feasible(from, to) {
+					current, improved = next, true
+				} else {
+					s.owner[u] = from
+				}
+			}
+		}
+
+		for u := range s.units {
+			for v := u + 1; v < len(s.units); v++ {
+				a, b := s.owner[u], s.owner[v]
+				if a == b || a < 0 || b < 0 {
+					continue
+				}
+				s.owner[u], s.owner[v] = b, a
+				if next := s.evaluate(); next.better(current) && s.feasible(a, b) {
+					current, improved = next, true
+				} else {
+					s.owner[u], s.owner[v] = a, b
+				}
+			}
+		}
+
+		if !improved {
+			return
+		}
+	}
+}
+
+// evaluate scores the current owners.
+func (s *preferenceSearch) evaluate() objective {
+	var o objective
+	earned := make(loads, len(s.base))
+	for i, person := range s.base {
+		earned[i] = balance(person)
+		total, _, _ := Satisfaction(person)
+		o.satisfaction += total
+	}
+
+	for u, unit := range s.units {
+		i := s.owner[u]
+		if i < 0 {
+			o.unassigned += len(unit)
+			continue
+		}
+		for _, chore := range unit {
+			earned[i] += chore.Earned
+		}
+		o.satisfaction += unitRating(s.base[i], unit)
+	}
+
+	lo, hi := earned.span()
+	o.gap = hi - lo
+	o.excess = max(o.gap-s.opts.Tolerance, 0)
+	return o
+}
+
+// feasible reports whether the people at the given indexes (-1 for nobody) can take
+// every unit they currently own.
+func (s *preferenceSearch) feasible(people ...int) bool {
+	for _, i := range people {
+		if i < 0 {
+			continue
+		}
+		if _, ok := s.build(i); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// build hands person i the units they own, in order. ok is false if they can't take
+// them all.
+func (s *preferenceSearch) build(i int) (person models.Person, ok bool) {
+	person = clonePeople(s.base[i : i+1])[0]
+	for u, unit := range s.units {
+		if s.owner[u] != i {
+			continue
+		}
+		if !s.opts.canTakeAll(person, unit) {
+			return person, false
+		}
+		for _, chore := range unit {
+			s.opts.assign(&person, chore)
+		}
+	}
+	return person, true
+}
+
+func (s *preferenceSearch) result() *models.DistributionResult {
+	result := &models.DistributionResult{
+		People:   make([]models.Person, len(s.base)),
+		Strategy: "preference",
+		Weights:  s.opts.Weights,
+	}
+	for i := range s.base {
+		result.People[i], _ = s.build(i)
+	}
+
+	// Each person takes their units in order, so handing them all out in order explains
+	// every decision as it would have stood
+	people := clonePeople(s.base)
+	var unassigned []models.Chore
+	for u, unit := range s.units {
+		i := s.owner[u]
+		if i < 0 {
+			unassigned = append(unassigned, unit...)
+			continue
+		}
+		if s.opts.Trace != nil {
+			s.opts.decide(unit, people, []int{i}, nil, preferenceRule(unitRating(people[i], unit)))
+			for _, chore := range unit {
+				s.opts.assign(&people[i], chore)
+			}
+		}
+	}
+	for _, bundle := range s.opts.bundle(unassigned) {
+		result.Unassigned = append(result.Unassigned, newBundleUnassigned(bundle, result.People, s.opts)...)
+	}
+
+	result.Fairness = ComputeFairness(result.People)
+	return result
+}
+
+// preferenceRule describes why the preference strategy gave chores to someone who
+// rates them as given.
+func preferenceRule(rating int) string {
+	switch {
+	case rating > 0:
+		return "loves it, within the earnings tolerance"
+	case rating < 0:
+		return "no one who minds it less could take it within the earnings tolerance"
+	}
+	return "the best trade-off between ratings and the earnings gap"
+}
+
+// unitRating adds up how much the person likes each chore in the unit.
+func unitRating(person models.Person, unit []models.Chore) int {
+	rating := 0
+	for _, chore := range unit {
+		rating += Rating(person, chore)
+	}
+	return rating
+}
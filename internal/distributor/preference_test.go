@@ -0,0 +1,103 @@
+package distributor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+func TestPreference_GivesLovedChores(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}, Preferences: map[string]string{"Dishes": models.Love, "Trash": models.Hate}},
+		{Name: "Bob", Chores: []models.Chore{}, Preferences: map[string]string{"Trash": models.Love, "Dishes": models.Hate}},
+	}
+	chores := []models.Chore{
+		{Name: "Dishes", Earned: 3, Difficulty: 2},
+		{Name: "Trash", Earned: 3, Difficulty: 2},
+		{Name: "Vacuum", Earned: 2, Difficulty: 2},
+		{Name: "Laundry", Earned: 2, Difficulty: 2},
+	}
+
+	for seed := uint64(1); seed <= 20; seed++ {
+		result := Preference{}.Distribute(chores, people, NewRand(seed), DefaultOptions())
+		if got := holders(result, "Dishes"); len(got) != 1 || got[0] != "Alice" {
+			t.Fatalf("Seed %d: expected Alice to get the dishes, got %v", seed, got)
+		}
+		if got := holders(result, "Trash"); len(got) != 1 || got[0] != "Bob" {
+			t.Fatalf("Seed %d: expected Bob to get the trash, got %v", seed, got)
+		}
+		if result.Fairness.EarnedSpread != 0 {
+			t.Errorf("Seed %d: expected even earnings, got a gap of $%d", seed, result.Fairness.EarnedSpread)
+		}
+	}
+}
+
+func TestPreference_Tolerance(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}, Preferences: map[string]string{"Dishes": models.Love, "Windows": models.Love}},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+	chores := []models.Chore{
+		{Name: "Dishes", Earned: 3, Difficulty: 2},
+		{Name: "Windows", Earned: 3, Difficulty: 2},
+	}
+
+	opts := DefaultOptions()
+	opts.Tolerance = 0
+	result := Preference{}.Distribute(chores, people, NewRand(1), opts)
+	if result.Fairness.EarnedSpread != 0 {
+		t.Errorf("Expected no earnings gap with no tolerance, got $%d", result.Fairness.EarnedSpread)
+	}
+
+	opts.Tolerance = 6
+	result = Preference{}.Distribute(chores, people, NewRand(1), opts)
+	if total, loved, _ := Satisfaction(result.People[0]); total != 2 || loved != 2 {
+		t.Errorf("Expected Alice to get both chores she loves within a $6 tolerance, got %+v", result.People[0].Chores)
+	}
+}
+
+func TestPreference_RespectsConstraints(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}, EffortCapacity: 4,
+			Preferences: map[string]string{"Dishes": models.Love, "Trash": models.Love, "Vacuum": models.Love}},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+	chores := []models.Chore{
+		{Name: "Dishes", Earned: 1, Difficulty: 2},
+		{Name: "Trash", Earned: 1, Difficulty: 2},
+		{Name: "Vacuum", Earned: 1, Difficulty: 2},
+	}
+	opts := DefaultOptions()
+	opts.Tolerance = 10
+
+	result := Preference{}.Distribute(chores, people, NewRand(1), opts)
+	if len(result.Unassigned) != 0 || result.People[0].TotalDifficulty > 4 {
+		t.Errorf("Expected Alice kept within her capacity, got %+v", result.People[0])
+	}
+}
+
+func TestPrintDistribution_Satisfaction(t *testing.T) {
+	result := &models.DistributionResult{
+		People: []models.Person{
+			{
+				Name:        "Alice",
+				Chores:      []models.Chore{{Name: "Dishes", Earned: 3}, {Name: "Trash", Earned: 2}, {Name: "Vacuum", Earned: 2}},
+				Preferences: map[string]string{"Dishes": models.Love, "Vacuum": models.Love, "Trash": models.Hate},
+				TotalEarned: 7,
+			},
+			{Name: "Bob", TotalEarned: 4},
+		},
+		Fairness: models.Fairness{EarnedSpread: 3},
+	}
+
+	var buf bytes.Buffer
+	PrintDistribution(&buf, result, PrintOptions{Verbose: true})
+	output := buf.String()
+	for _, want := range []string{"Satisfaction: +1 (2 loved, 1 hated)", "Satisfaction: +1 total, with an earnings gap of $3"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in the output, got:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package distributor
+
+import (
+	"math/rand/v2"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// RoundRobin deals chores out like cards: largest first, each to the next person in
+// turn who is eligible and has capacity for it, starting from a random person. Weights
+// only affect the order the chores are dealt in; with rotation enabled, people who had
+// a chore recently are passed over in favor of someone who did not. Team chores are
+// handed out first, the same way as by the greedy strategy, and chores kept together
+// are dealt as one.
+type RoundRobin struct{}
+
+func (RoundRobin) Name() string { return "round-robin" }
+
+func (RoundRobin) Distribute(chores []models.Chore, people []models.Person, rng *rand.Rand, opts Options) *models.DistributionResult {
+	result := &models.DistributionResult{
+		People:   clonePeople(people),
+		Strategy: "round-robin",
+		Weights:  opts.Weights,
+	}
+	assigned := result.People
+	chores, result.Unassigned = opts.assignTeams(chores, assigned, rng)
+
+	sortedChores := sortChores(chores, rng, opts.Weights)
+
+	next := 0
+	if len(assigned) > 0 {
+		next = rng.IntN(len(assigned))
+	}
+
+	for _, bundle := range opts.bundle(sortedChores) {
+		// Take the first eligible person in turn with capacity, passing over anyone who had
+		// the chore more recently than someone later in the rotation.
+		chosen, first := -1, -1
+		for n := 0; n < len(assigned); n++ {
+			i := (next + n) % len(assigned)
+			if !opts.canTakeAll(assigned[i], bundle) {
+				continue
+			}
+			if first == -1 {
+				first = i
+			}
+			if chosen == -1 || opts.bundleRepeats(assigned[i], bundle) < opts.bundleRepeats(assigned[chosen], bundle) {
+				chosen = i
+			}
+		}
+
+		if chosen == -1 {
+			result.Unassigned = append(result.Unassigned, newBundleUnassigned(bundle, assigned, opts)...)
+			continue
+		}
+
+		rule := "next in turn"
+		if chosen != first {
+			rule = "next in turn who had it fewer times recently"
+		}
+		opts.decide(bundle, assigned, []int{chosen}, nil, rule)
+		for _, chore := range bundle {
+			opts.assign(&assigned[chosen], chore)
+		}
+		next = (chosen + 1) % len(assigned)
+	}
+
+	result.Fairness = ComputeFairness(assigned)
+	return result
+}
+
+func init() {
+	Register(RoundRobin{})
+}
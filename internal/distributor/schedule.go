@@ -0,0 +1,160 @@
+package distributor
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+	"github.com/faradayfan/chore-distributor/internal/recurrence"
+)
+
+// noDay is the day offset of a chore that is not scheduled on a particular day.
+const noDay = -1
+
+// DayCapacity returns the most total difficulty the person can be scheduled for on the
+// given day of the week. limited is false if there is no limit.
+func DayCapacity(person models.Person, day time.Weekday) (limit int, limited bool) {
+	if name, ok := dayCapacityName(person, day); ok {
+		return person.DayCapacity[name], true
+	}
+	if person.DailyCapacity > 0 {
+		return person.DailyCapacity, true
+	}
+	return 0, false
+}
+
+// dayCapacityName returns the name the day is given under in the person's DayCapacity,
+// such as "Sat" or "saturday", if it has an entry of its own.
+func dayCapacityName(person models.Person, day time.Weekday) (string, bool) {
+	for name := range person.DayCapacity {
+		if wd, err := recurrence.ParseWeekday(name); err == nil && wd == day {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// weekStart is midnight on the first day of the week being planned.
+func (o Options) weekStart() time.Time {
+	d := o.date()
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, d.Location())
+}
+
+// dayOffset returns which day of the week starting at start the date falls on, or
+// noDay if it is not in that week.
+func dayOffset(start, date time.Time) int {
+	if date.IsZero() {
+		return noDay
+	}
+	for d := 0; d < 7; d++ {
+		if day := start.AddDate(0, 0, d); date.Year() == day.Year() && date.YearDay() == day.YearDay() {
+			return d
+		}
+	}
+	return noDay
+}
+
+// dayLoads returns the total difficulty of the person's chores scheduled on each day
+// of the week starting at start.
+func dayLoads(person models.Person, start time.Time) [7]int {
+	var loads [7]int
+	for _, chore := range person.Chores {
+		if d := dayOffset(start, chore.Date); d != noDay {
+			loads[d] += chore.Difficulty
+		}
+	}
+	return loads
+}
+
+// scheduled reports whether the chore goes on a particular day of the plan.
+func (o Options) scheduled(chore models.Chore) bool {
+	return !chore.Date.IsZero() || len(chore.Days) > 0 || o.WeekPlan
+}
+
+// room returns how much more difficulty the person can take on day d of the week,
+// given what is already scheduled there.
+func (o Options) room(person models.Person, loads [7]int, d int) int {
+	limit, limited := DayCapacity(person, o.weekStart().AddDate(0, 0, d).Weekday())
+	if !limited {
+		return math.MaxInt
+	}
+	return limit - loads[d]
+}
+
+// pickDay chooses the day of the week (an offset from the week start) to schedule the
+// chore on for a person who already has loads scheduled on each day. A chore that is
+// already dated keeps its day; otherwise the allowed day with the most room left is
+// used, then the least busy, then the earliest. It returns noDay for a chore that is
+// not scheduled on a day, and ok is false if no allowed day has room for it.
+func (o Options) pickDay(person models.Person, loads [7]int, chore models.Chore) (day int, ok bool) {
+	if !o.scheduled(chore) {
+		return noDay, true
+	}
+
+	start := o.weekStart()
+	if !chore.Date.IsZero() {
+		d := dayOffset(start, chore.Date)
+		if d == noDay {
+			return noDay, true
+		}
+		return d, o.room(person, loads, d) >= chore.Difficulty
+	}
+
+	best, bestRoom := noDay, 0
+	for d := 0; d < 7; d++ {
+		if !recurrence.AllowedOn(chore, start.AddDate(0, 0, d).Weekday()) {
+			continue
+		}
+		room := o.room(person, loads, d)
+		if room < chore.Difficulty {
+			continue
+		}
+		if best == noDay || room > bestRoom || (room == bestRoom && loads[d] < loads[best]) {
+			best, bestRoom = d, room
+		}
+	}
+	return best, best != noDay
+}
+
+// hasDay reports whether the person has room for the chore on a day it is allowed.
+func (o Options) hasDay(person models.Person, chore models.Chore) bool {
+	_, ok := o.pickDay(person, dayLoads(person, o.weekStart()), chore)
+	return ok
+}
+
+// assign gives the chore to the person, scheduling it on the day pickDay chooses.
+func (o Options) assign(person *models.Person, chore models.Chore) {
+	start := o.weekStart()
+	if d, ok := o.pickDay(*person, dayLoads(*person, start), chore); ok && d != noDay {
+		chore.Date = start.AddDate(0, 0, d)
+	}
+	person.Chores = append(person.Chores, chore)
+	person.TotalDifficulty += chore.Difficulty
+	person.TotalEarned += chore.Earned
+}
+
+// dayReason explains why the person has no room for the chore on any allowed day.
+func (o Options) dayReason(person models.Person, chore models.Chore) string {
+	start := o.weekStart()
+	loads := dayLoads(person, start)
+
+	fixed := dayOffset(start, chore.Date)
+
+	var full []string
+	for d := 0; d < 7; d++ {
+		date := start.AddDate(0, 0, d)
+		if fixed != noDay && fixed != d {
+			continue
+		}
+		if !recurrence.AllowedOn(chore, date.Weekday()) {
+			continue
+		}
+		full = append(full, fmt.Sprintf("%s (%d left)", date.Format("Mon"), max(o.room(person, loads, d), 0)))
+	}
+	if len(full) == 0 {
+		return "no allowed day this week"
+	}
+	return fmt.Sprintf("no room on %s, chore needs %d", strings.Join(full, ", "), chore.Difficulty)
+}
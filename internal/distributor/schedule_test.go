@@ -0,0 +1,185 @@
+package distributor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// planStart is a Monday, so day offsets line up with weekdays.
+var planStart = time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC)
+
+func TestPickDay_AllowedDays(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Date = planStart
+	person := models.Person{Name: "Alice"}
+
+	day, ok := opts.pickDay(person, [7]int{}, models.Chore{Name: "Trash", Days: []string{"Tue"}})
+	if !ok || day != 1 {
+		t.Errorf("Expected Trash on Tuesday (1), got %d, %v", day, ok)
+	}
+
+	day, ok = opts.pickDay(person, [7]int{}, models.Chore{Name: "Kitchen"})
+	if !ok || day != noDay {
+		t.Errorf("Expected an unscheduled chore without a week plan, got %d, %v", day, ok)
+	}
+}
+
+func TestPickDay_LeastBusyDayWithRoom(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Date = planStart
+	opts.WeekPlan = true
+	person := models.Person{Name: "Alice", DailyCapacity: 4, DayCapacity: map[string]int{"Wed": 0}}
+
+	// Monday and Tuesday are full, Wednesday is off and Thursday is half full.
+	loads := [7]int{4, 3, 0, 2, 0, 0, 0}
+	chore := models.Chore{Name: "Laundry", Difficulty: 2, Days: []string{"Mon", "Tue", "Wed", "Thu"}}
+
+	day, ok := opts.pickDay(person, loads, chore)
+	if !ok || day != 3 {
+		t.Errorf("Expected Thursday (3), got %d, %v", day, ok)
+	}
+
+	loads[3] = 3
+	if _, ok := opts.pickDay(person, loads, chore); ok {
+		t.Error("Expected no day with room")
+	}
+
+	// A chore already dated keeps its day.
+	dated := models.Chore{Name: "Dishes", Difficulty: 1, Date: planStart.AddDate(0, 0, 4)}
+	if day, ok := opts.pickDay(person, loads, dated); !ok || day != 4 {
+		t.Errorf("Expected a dated chore to stay on Friday (4), got %d, %v", day, ok)
+	}
+}
+
+func TestWeekPlan_SpreadsChoresAcrossDays(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Date = planStart
+	opts.WeekPlan = true
+
+	var chores []models.Chore
+	for i := 0; i < 7; i++ {
+		chores = append(chores, models.Chore{Name: "Chore", Difficulty: 1, Earned: 1})
+	}
+	people := []models.Person{{Name: "Alice", Chores: []models.Chore{}}}
+
+	result := Greedy{}.Distribute(chores, people, NewRand(1), opts)
+	seen := make(map[time.Weekday]bool)
+	for _, chore := range result.People[0].Chores {
+		seen[chore.Date.Weekday()] = true
+	}
+	if len(seen) != 7 {
+		t.Errorf("Expected one chore on each day, got days %v", seen)
+	}
+}
+
+func TestStrategies_ScheduleInvariants(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Date = planStart
+	opts.WeekPlan = true
+
+	for _, name := range Strategies() {
+		strategy, _ := Lookup(name)
+
+		t.Run(name, func(t *testing.T) {
+			// Recurring instances, chores limited to certain days and chores that can
+			// go on any day, for people with daily limits.
+			var chores []models.Chore
+			for d := 0; d < 7; d++ {
+				chores = append(chores, models.Chore{Name: "Dishes", Difficulty: 2, Earned: 1, Date: planStart.AddDate(0, 0, d)})
+			}
+			chores = append(chores,
+				models.Chore{Name: "Trash", Difficulty: 2, Earned: 2, Days: []string{"Tue"}},
+				models.Chore{Name: "Mow", Difficulty: 5, Earned: 5, Days: []string{"Sat", "Sun"}},
+				models.Chore{Name: "Bathroom", Difficulty: 4, Earned: 4},
+				models.Chore{Name: "Garage", Difficulty: 6, Earned: 6, Days: []string{"Sat"}},
+			)
+			people := []models.Person{
+				{Name: "Alice", DailyCapacity: 4, DayCapacity: map[string]int{"Sat": 6}, Chores: []models.Chore{}},
+				{Name: "Bob", DailyCapacity: 3, DayCapacity: map[string]int{"Tue": 0}, Chores: []models.Chore{}},
+			}
+
+			for seed := uint64(1); seed <= 25; seed++ {
+				result := strategy.Distribute(chores, people, NewRand(seed), opts)
+				checkInvariants(t, seed, chores, people, result, opts)
+
+				dishes := make(map[time.Time]bool)
+				for _, person := range result.People {
+					for _, chore := range person.Chores {
+						if chore.Date.IsZero() {
+							t.Errorf("seed %d: %s was not scheduled on a day", seed, chore.Name)
+						}
+						if chore.Name == "Dishes" {
+							dishes[chore.Date] = true
+						}
+					}
+				}
+				for _, u := range result.Unassigned {
+					if u.Chore.Name == "Dishes" {
+						dishes[u.Chore.Date] = true
+					}
+				}
+				if len(dishes) != 7 {
+					t.Errorf("seed %d: expected Dishes to keep one instance per day, got %v", seed, dishes)
+				}
+			}
+		})
+	}
+}
+
+func TestNewUnassigned_NoRoomOnAllowedDays(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Date = planStart
+	person := models.Person{
+		Name:          "Alice",
+		DailyCapacity: 3,
+		Chores:        []models.Chore{{Name: "Dishes", Difficulty: 2, Date: planStart.AddDate(0, 0, 1)}},
+	}
+	chore := models.Chore{Name: "Trash", Difficulty: 2, Days: []string{"Tue"}}
+
+	u := newUnassigned(chore, []models.Person{person}, opts)
+	if u.Reason != "no one has capacity" {
+		t.Errorf("Unexpected reason %q", u.Reason)
+	}
+	if got := u.Excluded[0].Reason; got != "no room on Tue (1 left), chore needs 2" {
+		t.Errorf("Unexpected reason for Alice: %q", got)
+	}
+}
+
+func TestPrintDistribution_GroupsByDay(t *testing.T) {
+	result := &models.DistributionResult{
+		People: []models.Person{{
+			Name:              "Alice",
+			DailyCapacity:     4,
+			PreAssignedChores: []models.Chore{{Name: "Bedroom", Difficulty: 1, Earned: 1}},
+			Chores: []models.Chore{
+				{Name: "Trash", Difficulty: 2, Earned: 2, Date: planStart.AddDate(0, 0, 1)},
+				{Name: "Dishes", Difficulty: 1, Earned: 1, Date: planStart},
+				{Name: "Dishes", Difficulty: 1, Earned: 1, Date: planStart.AddDate(0, 0, 1)},
+			},
+			TotalDifficulty: 5,
+			TotalEarned:     5,
+		}},
+	}
+
+	var buf bytes.Buffer
+	PrintDistribution(&buf, result, PrintOptions{Verbose: true})
+	out := buf.String()
+
+	want := []string{
+		"  Chores:\n    - Bedroom",
+		"  Monday, Jun 2 (Effort: 1 / 4):\n    - Dishes",
+		"  Tuesday, Jun 3 (Effort: 3 / 4):\n    - Trash (Difficulty: 2, Earns: $2)\n    - Dishes",
+	}
+	for _, w := range want {
+		if !strings.Contains(out, w) {
+			t.Errorf("Expected output to contain %q, got:\n%s", w, out)
+		}
+	}
+	if strings.Index(out, "Monday") > strings.Index(out, "Tuesday") {
+		t.Error("Expected days in date order")
+	}
+}
@@ -0,0 +1,248 @@
+package distributor
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// Stats describes how a number varied across simulated runs.
+type Stats struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stdDev"`
+	Min    int     `json:"min"`
+	P10    int     `json:"p10"`
+	Median int     `json:"median"`
+	P90    int     `json:"p90"`
+	Max    int     `json:"max"`
+}
+
+// newStats summarizes the values, which must not be empty.
+func newStats(values []int) Stats {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	sum := 0
+	for _, v := range sorted {
+		sum += v
+	}
+	mean := float64(sum) / float64(len(sorted))
+	variance := 0.0
+	for _, v := range sorted {
+		variance += (float64(v) - mean) * (float64(v) - mean)
+	}
+
+	// Nearest-rank percentiles
+	percentile := func(p int) int {
+		rank := (p*len(sorted) + 99) / 100
+		return sorted[max(rank, 1)-1]
+	}
+	return Stats{
+		Mean:   mean,
+		StdDev: math.Sqrt(variance / float64(len(sorted))),
+		Min:    sorted[0],
+		P10:    percentile(10),
+		Median: percentile(50),
+		P90:    percentile(90),
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+// Simulation is the outcome of distributing the same week many times over.
+type Simulation struct {
+	Strategy string `json:"strategy"`
+	Runs     int    `json:"runs"`
+	Seed     uint64 `json:"seed"`
+	// Incomplete is how many runs left at least one chore unassigned.
+	Incomplete       int           `json:"incomplete"`
+	EarnedSpread     Stats         `json:"earnedSpread"`
+	DifficultySpread Stats         `json:"difficultySpread"`
+	People           []PersonStats `json:"people"`
+	Chores           []ChoreStats  `json:"chores"`
+}
+
+// PersonStats describes what a person was given across simulated runs.
+type PersonStats struct {
+	Name       string `json:"name"`
+	Earned     Stats  `json:"earned"`
+	Difficulty Stats  `json:"difficulty"`
+}
+
+// ChoreStats describes who was given a chore across simulated runs.
+type ChoreStats struct {
+	Name string `json:"name"`
+	// Instances is how many times the chore comes up each week.
+	Instances int `json:"instances"`
+	// Given counts the instances each person was given over all runs, by name. A team
+	// chore counts for everyone on the team.
+	Given map[string]int `json:"given"`
+	// Unassigned counts the instances left unassigned over all runs.
+	Unassigned int `json:"unassigned"`
+}
+
+// simRun is what one simulated run contributes to a Simulation.
+type simRun struct {
+	earned, difficulty []int
+	fairness           models.Fairness
+	given              map[string]map[string]int
+	unassigned         map[string]int
+}
+
+// Simulate distributes the chores runs times with the strategy, spread over as many
+// goroutines as there are CPUs, and summarizes the results. Each run gets its own
+// seed drawn from seed, so the same seed gives the same simulation. runs must be at
+// least 1.
+func Simulate(strategy Strategy, chores []models.Chore, people []models.Person, opts Options, runs int, seed uint64) Simulation {
+	opts.Trace = nil
+
+	rng := NewRand(seed)
+	seeds := make([]uint64, runs)
+	for i := range seeds {
+		seeds[i] = rng.Uint64()
+	}
+
+	results := make([]simRun, runs)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(runtime.GOMAXPROCS(0), runs) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = newSimRun(strategy.Distribute(chores, people, NewRand(seeds[i]), opts))
+			}
+		}()
+	}
+	for i := range runs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	return summarize(strategy.Name(), seed, chores, people, results)
+}
+
+// newSimRun picks out what a Simulation needs from a distribution.
+func newSimRun(result *models.DistributionResult) simRun {
+	run := simRun{
+		fairness:   result.Fairness,
+		given:      make(map[string]map[string]int),
+		unassigned: make(map[string]int),
+	}
+	for _, person := range result.People {
+		run.earned = append(run.earned, person.TotalEarned)
+		run.difficulty = append(run.difficulty, person.TotalDifficulty)
+		for _, chore := range person.Chores {
+			if run.given[chore.Name] == nil {
+				run.given[chore.Name] = make(map[string]int)
+			}
+			run.given[chore.Name][person.Name]++
+		}
+	}
+	for _, u := range result.Unassigned {
+		run.unassigned[u.Chore.Name]++
+	}
+	return run
+}
+
+// summarize adds up the runs.
+func summarize(strategy string, seed uint64, chores []models.Chore, people []models.Person, runs []simRun) Simulation {
+	sim := Simulation{
+		Strategy: strategy,
+		Runs:     len(runs),
+		Seed:     seed,
+	}
+
+	for _, chore := range chores {
+		k := slices.IndexFunc(sim.Chores, func(c ChoreStats) bool { return c.Name == chore.Name })
+		if k < 0 {
+			sim.Chores = append(sim.Chores, ChoreStats{Name: chore.Name, Given: make(map[string]int)})
+			k = len(sim.Chores) - 1
+		}
+		sim.Chores[k].Instances++
+	}
+
+	earnedSpread := make([]int, len(runs))
+	difficultySpread := make([]int, len(runs))
+	for r, run := range runs {
+		earnedSpread[r] = run.fairness.EarnedSpread
+		difficultySpread[r] = run.fairness.DifficultySpread
+		if len(run.unassigned) > 0 {
+			sim.Incomplete++
+		}
+		for k := range sim.Chores {
+			name := sim.Chores[k].Name
+			for person, n := range run.given[name] {
+				sim.Chores[k].Given[person] += n
+			}
+			sim.Chores[k].Unassigned += run.unassigned[name]
+		}
+	}
+	sim.EarnedSpread = newStats(earnedSpread)
+	sim.DifficultySpread = newStats(difficultySpread)
+
+	for i, person := range people {
+		earned := make([]int, len(runs))
+		difficulty := make([]int, len(runs))
+		for r, run := range runs {
+			earned[r] = run.earned[i]
+			difficulty[r] = run.difficulty[i]
+		}
+		sim.People = append(sim.People, PersonStats{
+			Name:       person.Name,
+			Earned:     newStats(earned),
+			Difficulty: newStats(difficulty),
+		})
+	}
+	return sim
+}
+
+// PrintSimulation writes a simulation as tables: the spread of each person's earnings
+// and difficulty, and how often each person got each chore.
+func PrintSimulation(w io.Writer, sim Simulation) {
+	fmt.Fprintf(w, "\n=== Simulated %d Runs (%s, seed %d) ===\n\n", sim.Runs, sim.Strategy, sim.Seed)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	printStats := func(title, unit string, stats func(PersonStats) Stats) {
+		fmt.Fprintf(tw, "%s\tMean\tStd Dev\tMin\t10%%\tMedian\t90%%\tMax\n", title)
+		for _, p := range sim.People {
+			s := stats(p)
+			fmt.Fprintf(tw, "%s\t%s%.2f\t%.2f\t%s%d\t%s%d\t%s%d\t%s%d\t%s%d\n",
+				p.Name, unit, s.Mean, s.StdDev, unit, s.Min, unit, s.P10, unit, s.Median, unit, s.P90, unit, s.Max)
+		}
+		fmt.Fprintln(tw)
+	}
+	printStats("Earnings", "$", func(p PersonStats) Stats { return p.Earned })
+	printStats("Difficulty", "", func(p PersonStats) Stats { return p.Difficulty })
+
+	header := []string{"Chore"}
+	for _, p := range sim.People {
+		header = append(header, p.Name)
+	}
+	header = append(header, "Unassigned")
+	fmt.Fprintf(tw, "%s\n", strings.Join(header, "\t"))
+	for _, c := range sim.Chores {
+		total := float64(c.Instances * sim.Runs)
+		row := []string{c.Name}
+		for _, p := range sim.People {
+			row = append(row, fmt.Sprintf("%.1f%%", 100*float64(c.Given[p.Name])/total))
+		}
+		row = append(row, fmt.Sprintf("%.1f%%", 100*float64(c.Unassigned)/total))
+		fmt.Fprintf(tw, "%s\n", strings.Join(row, "\t"))
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\nEarnings gap: $%.2f on average, $%d or less in 90%% of runs, $%d at worst\n",
+		sim.EarnedSpread.Mean, sim.EarnedSpread.P90, sim.EarnedSpread.Max)
+	fmt.Fprintf(w, "Difficulty gap: %.2f on average, %d or less in 90%% of runs, %d at worst\n",
+		sim.DifficultySpread.Mean, sim.DifficultySpread.P90, sim.DifficultySpread.Max)
+	fmt.Fprintf(w, "Runs with a chore left unassigned: %d of %d (%.1f%%)\n",
+		sim.Incomplete, sim.Runs, 100*float64(sim.Incomplete)/float64(sim.Runs))
+}
@@ -0,0 +1,97 @@
+package distributor
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+func TestNewStats(t *testing.T) {
+	values := []int{5, 1, 4, 2, 3, 6, 8, 7, 10, 9}
+
+	got := newStats(values)
+	want := Stats{Mean: 5.5, Min: 1, P10: 1, Median: 5, P90: 9, Max: 10}
+	got.StdDev = 0
+	if got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+	if values[0] != 5 {
+		t.Errorf("Expected the values to be left in order, got %v", values)
+	}
+
+	if s := newStats([]int{4, 4}); s.StdDev != 0 || s.Mean != 4 {
+		t.Errorf("Expected no spread, got %+v", s)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}, EffortCapacity: 6},
+		{Name: "Bob", Chores: []models.Chore{}, Age: 8},
+	}
+	chores := []models.Chore{
+		{Name: "Dishes", Earned: 3, Difficulty: 3},
+		{Name: "Dishes", Earned: 3, Difficulty: 3},
+		{Name: "Trash", Earned: 2, Difficulty: 2},
+		{Name: "Mow", Earned: 6, Difficulty: 8, MinAge: 12},
+	}
+
+	sim := Simulate(Greedy{}, chores, people, DefaultOptions(), 200, 7)
+	if sim.Runs != 200 || sim.Strategy != "greedy" || sim.Seed != 7 {
+		t.Errorf("Unexpected simulation: %+v", sim)
+	}
+	if len(sim.People) != 2 || len(sim.Chores) != 3 {
+		t.Fatalf("Expected 2 people and 3 chores, got %+v", sim)
+	}
+
+	// Mow is too hard for Alice and Bob is too young, so it's never assigned
+	if sim.Incomplete != 200 {
+		t.Errorf("Expected every run incomplete, got %d", sim.Incomplete)
+	}
+	dishes, mow := sim.Chores[0], sim.Chores[2]
+	if dishes.Instances != 2 || dishes.Given["Alice"]+dishes.Given["Bob"] != 400 || dishes.Unassigned != 0 {
+		t.Errorf("Expected both Dishes handed out every run, got %+v", dishes)
+	}
+	if mow.Unassigned != 200 || len(mow.Given) != 0 {
+		t.Errorf("Expected Mow never handed out, got %+v", mow)
+	}
+	for _, p := range sim.People {
+		if p.Earned.Min < 2 || p.Earned.Max > 6 {
+			t.Errorf("Unexpected earnings for %s: %+v", p.Name, p.Earned)
+		}
+	}
+
+	again := Simulate(Greedy{}, chores, people, DefaultOptions(), 200, 7)
+	if !reflect.DeepEqual(sim, again) {
+		t.Errorf("Expected the same seed to give the same simulation")
+	}
+}
+
+func TestPrintSimulation(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}},
+	}
+	chores := []models.Chore{
+		{Name: "Dishes", Earned: 3, Difficulty: 3},
+		{Name: "Trash", Earned: 3, Difficulty: 3},
+	}
+
+	var buf bytes.Buffer
+	PrintSimulation(&buf, Simulate(Greedy{}, chores, people, DefaultOptions(), 10, 1))
+	output := buf.String()
+
+	for _, want := range []string{
+		"=== Simulated 10 Runs (greedy, seed 1) ===",
+		"Alice", "$3.00",
+		"Earnings gap: $0.00 on average",
+		"Runs with a chore left unassigned: 0 of 10 (0.0%)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package distributor
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// DefaultStrategy is used when neither the config nor the command line names one.
+const DefaultStrategy = "greedy"
+
+// Strategy is an algorithm for assigning chores to people. Every strategy must respect
+// each person's EffortCapacity, keep pre-assigned chores as they are, never modify the
+// people passed in, and report every chore it could not assign in the result.
+type Strategy interface {
+	Name() string
+	Distribute(chores []models.Chore, people []models.Person, rng *rand.Rand, opts Options) *models.DistributionResult
+}
+
+var registry = map[string]Strategy{}
+
+// Register makes a strategy available by name. It panics if the name is already taken.
+func Register(s Strategy) {
+	if _, exists := registry[s.Name()]; exists {
+		panic(fmt.Sprintf("distributor: strategy %q registered twice", s.Name()))
+	}
+	registry[s.Name()] = s
+}
+
+// Lookup returns the strategy registered under name.
+func Lookup(name string) (Strategy, error) {
+	s, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy %q (available: %s)", name, strings.Join(Strategies(), ", "))
+	}
+	return s, nil
+}
+
+// Strategies returns the names of all registered strategies in alphabetical order.
+func Strategies() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Greedy gives each chore, largest first, to the person with the lowest weighted
+// score so far. With the default weights it behaves exactly like Distribute.
+type Greedy struct{}
+
+func (Greedy) Name() string { return "greedy" }
+
+func (Greedy) Distribute(chores []models.Chore, people []models.Person, rng *rand.Rand, opts Options) *models.DistributionResult {
+	return distributeGreedy(chores, people, rng, opts)
+}
+
+func init() {
+	Register(Greedy{})
+}
@@ -0,0 +1,243 @@
+package distributor
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+	"github.com/faradayfan/chore-distributor/internal/recurrence"
+)
+
+func TestStrategies_Registered(t *testing.T) {
+	for _, name := range []string{"greedy", "round-robin", "optimal"} {
+		s, err := Lookup(name)
+		if err != nil {
+			t.Errorf("Expected strategy %q to be registered: %v", name, err)
+			continue
+		}
+		if s.Name() != name {
+			t.Errorf("Strategy registered as %q reports name %q", name, s.Name())
+		}
+	}
+
+	if _, err := Lookup("nope"); err == nil {
+		t.Error("Expected an error for an unknown strategy")
+	}
+}
+
+func TestStrategies_Invariants(t *testing.T) {
+	for _, name := range Strategies() {
+		strategy, _ := Lookup(name)
+
+		t.Run(name, func(t *testing.T) {
+			// Mirrors the example config: a mix of unlimited people, capacity-limited
+			// people and a pre-assigned chore.
+			chores := []models.Chore{
+				{Name: "Family Room", Difficulty: 3, Earned: 2},
+				{Name: "Living Room", Difficulty: 4, Earned: 3},
+				{Name: "Kitchen", Difficulty: 6, Earned: 5},
+				{Name: "Dining Room", Difficulty: 4, Earned: 3},
+				{Name: "Mud Room", Difficulty: 3, Earned: 2},
+				{Name: "Bathroom", Difficulty: 5, Earned: 4},
+				{Name: "Garage", Difficulty: 12, Earned: 8},
+			}
+			people := []models.Person{
+				{Name: "Jeff", EffortCapacity: 10, Chores: []models.Chore{}},
+				{Name: "John", EffortCapacity: 0, Chores: []models.Chore{}},
+				{Name: "Kristen", EffortCapacity: 5, Chores: []models.Chore{}},
+				{
+					Name:              "Tommy",
+					EffortCapacity:    4,
+					PreAssignedChores: []models.Chore{{Name: "Clean Bedroom", Difficulty: 1, Earned: 1}},
+					TotalDifficulty:   1,
+					TotalEarned:       1,
+					Chores:            []models.Chore{},
+				},
+			}
+
+			for seed := uint64(1); seed <= 25; seed++ {
+				result := strategy.Distribute(chores, people, NewRand(seed), DefaultOptions())
+				checkInvariants(t, seed, chores, people, result, DefaultOptions())
+			}
+		})
+	}
+}
+
+// invariantDate is the day eligibility is checked against in the invariant tests.
+var invariantDate = time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+func TestStrategies_EligibilityInvariants(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Date = invariantDate
+
+	for _, name := range Strategies() {
+		strategy, _ := Lookup(name)
+
+		t.Run(name, func(t *testing.T) {
+			// Each chore but the first two restricts who may take it, and nobody
+			// can drive to practice.
+			chores := []models.Chore{
+				{Name: "Family Room", Difficulty: 3, Earned: 2},
+				{Name: "Living Room", Difficulty: 4, Earned: 3},
+				{Name: "Kitchen", Difficulty: 6, Earned: 5, MinAge: 12},
+				{Name: "Dining Room", Difficulty: 4, Earned: 3},
+				{Name: "Mud Room", Difficulty: 3, Earned: 2, DeniedPeople: []string{"Kristen"}},
+				{Name: "Bathroom", Difficulty: 5, Earned: 4, Skills: []string{"scrubbing"}},
+				{Name: "Garage", Difficulty: 12, Earned: 8, AllowedPeople: []string{"Jeff", "John"}},
+				{Name: "Drive to Practice", Difficulty: 2, Earned: 2, Skills: []string{"driving"}},
+			}
+			people := []models.Person{
+				{Name: "Jeff", Age: 40, EffortCapacity: 10, Chores: []models.Chore{}},
+				{Name: "John", Birthdate: "1985-03-14", Skills: []string{"scrubbing"}, Chores: []models.Chore{}},
+				// Turns 12 the day after invariantDate.
+				{Name: "Kristen", Birthdate: "2013-06-02", Skills: []string{"scrubbing"}, EffortCapacity: 5, Chores: []models.Chore{}},
+				{
+					Name:              "Tommy",
+					Age:               8,
+					EffortCapacity:    4,
+					PreAssignedChores: []models.Chore{{Name: "Clean Bedroom", Difficulty: 1, Earned: 1}},
+					TotalDifficulty:   1,
+					TotalEarned:       1,
+					Chores:            []models.Chore{},
+				},
+			}
+
+			for seed := uint64(1); seed <= 25; seed++ {
+				result := strategy.Distribute(chores, people, NewRand(seed), opts)
+				checkInvariants(t, seed, chores, people, result, opts)
+
+				var drive *models.UnassignedChore
+				for i := range result.Unassigned {
+					if result.Unassigned[i].Chore.Name == "Drive to Practice" {
+						drive = &result.Unassigned[i]
+					}
+				}
+				if drive == nil || drive.Reason != "no one is eligible" {
+					t.Fatalf("seed %d: expected Drive to Practice unassigned with no one eligible, got %+v",
+						seed, drive)
+				}
+				if len(drive.Excluded) != len(people) {
+					t.Errorf("seed %d: expected a reason for every person, got %+v", seed, drive.Excluded)
+				}
+			}
+		})
+	}
+}
+
+func TestStrategies_AssignEverythingWithoutLimits(t *testing.T) {
+	for _, name := range Strategies() {
+		strategy, _ := Lookup(name)
+
+		t.Run(name, func(t *testing.T) {
+			chores := []models.Chore{
+				{Name: "Kitchen", Difficulty: 6, Earned: 5},
+				{Name: "Bathroom", Difficulty: 5, Earned: 4},
+				{Name: "Living Room", Difficulty: 4, Earned: 3},
+				{Name: "Garage", Difficulty: 12, Earned: 8},
+			}
+			people := []models.Person{
+				{Name: "Alice", Chores: []models.Chore{}},
+				{Name: "Bob", Chores: []models.Chore{}},
+			}
+
+			result := strategy.Distribute(chores, people, rand.New(rand.NewPCG(3, 4)), DefaultOptions())
+			if len(result.Unassigned) != 0 {
+				t.Errorf("Expected every chore assigned with unlimited capacity, got %d unassigned",
+					len(result.Unassigned))
+			}
+		})
+	}
+}
+
+func checkInvariants(t *testing.T, seed uint64, chores []models.Chore, people []models.Person, result *models.DistributionResult, opts Options) {
+	t.Helper()
+
+	if result.Strategy == "" {
+		t.Errorf("seed %d: result should record the strategy used", seed)
+	}
+
+	for _, person := range people {
+		if len(person.Chores) != 0 {
+			t.Fatalf("seed %d: input person %s was modified", seed, person.Name)
+		}
+	}
+
+	if len(result.People) != len(people) {
+		t.Fatalf("seed %d: expected %d people in result, got %d", seed, len(people), len(result.People))
+	}
+
+	seen := make(map[string]int)
+	for i, person := range result.People {
+		original := people[i]
+
+		// Pre-assigned chores are preserved and never handed out again.
+		if len(person.PreAssignedChores) != len(original.PreAssignedChores) {
+			t.Errorf("seed %d: %s pre-assigned chores changed", seed, person.Name)
+		}
+
+		difficulty, earned := original.TotalDifficulty, original.TotalEarned
+		for _, chore := range person.Chores {
+			seen[chore.Name]++
+			difficulty += chore.Difficulty
+			earned += chore.Earned
+		}
+		if difficulty != person.TotalDifficulty || earned != person.TotalEarned {
+			t.Errorf("seed %d: %s totals don't match their chores: difficulty %d vs %d, earned %d vs %d",
+				seed, person.Name, person.TotalDifficulty, difficulty, person.TotalEarned, earned)
+		}
+
+		if person.EffortCapacity > 0 && person.TotalDifficulty > person.EffortCapacity {
+			t.Errorf("seed %d: %s exceeded capacity: %d > %d",
+				seed, person.Name, person.TotalDifficulty, person.EffortCapacity)
+		}
+
+		daily := make(map[time.Time]int)
+		for _, chore := range person.Chores {
+			if !chore.Date.IsZero() {
+				daily[chore.Date] += chore.Difficulty
+				if !recurrence.AllowedOn(chore, chore.Date.Weekday()) {
+					t.Errorf("seed %d: %s scheduled on %v, not one of its days %v",
+						seed, chore.Name, chore.Date.Weekday(), chore.Days)
+				}
+			}
+		}
+		for date, load := range daily {
+			if limit, limited := DayCapacity(person, date.Weekday()); limited && load > limit {
+				t.Errorf("seed %d: %s exceeded daily capacity on %v: %d > %d",
+					seed, person.Name, date.Weekday(), load, limit)
+			}
+		}
+
+		for _, chore := range person.Chores {
+			if reason := Ineligibility(person, chore, opts.date()); reason != "" {
+				t.Errorf("seed %d: %s was given %s but is not eligible: %s",
+					seed, person.Name, chore.Name, reason)
+			}
+		}
+	}
+
+	for _, u := range result.Unassigned {
+		seen[u.Chore.Name]++
+
+		// A chore may only be left over if nobody eligible can fit it in the final
+		// distribution.
+		for _, person := range result.People {
+			if opts.canTake(person, u.Chore) {
+				t.Errorf("seed %d: %s was left unassigned but %s can take it",
+					seed, u.Chore.Name, person.Name)
+			}
+		}
+	}
+
+	// Recurring chores have several instances with the same name.
+	want := make(map[string]int)
+	for _, chore := range chores {
+		want[chore.Name]++
+	}
+	for name, n := range want {
+		if seen[name] != n {
+			t.Errorf("seed %d: chore %s accounted for %d times, want %d", seed, name, seen[name], n)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package distributor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// Surge raises the price of the chores nobody wants: each counts a point for every
+// one of the people who hates it, less one for every one who loves it, and one for
+// every recent week it was left unassigned (unwanted, by chore name). The bonus is
+// surge.Step dollars a point, up to surge.Max, and is added to Earned and recorded in
+// Surge on every instance of the chore. It returns the repriced chores and a record
+// of each bonus given.
+//
+// The history doesn't record chores being swapped after a distribution, so how often a
+// chore was left unassigned stands in for how often nobody wanted it.
+func Surge(chores []models.Chore, people []models.Person, unwanted map[string]int, surge models.Surge) ([]models.Chore, []models.PriceChange) {
+	step := surge.Step
+	if step <= 0 {
+		step = 1
+	}
+
+	var changes []models.PriceChange
+	bonuses := make(map[string]int)
+	repriced := make([]models.Chore, len(chores))
+	for i, chore := range chores {
+		bonus, seen := bonuses[chore.Name]
+		if !seen {
+			points, reason := unpopularity(chore, people, unwanted[chore.Name], surge.Weeks)
+			bonus = min(points*step, surge.Max)
+			bonuses[chore.Name] = bonus
+			if bonus > 0 {
+				changes = append(changes, models.PriceChange{
+					Chore:  chore.Name,
+					Base:   chore.Earned,
+					Bonus:  bonus,
+					Reason: reason,
+				})
+			}
+		}
+
+		chore.Earned += bonus
+		chore.Surge += bonus
+		repriced[i] = chore
+	}
+	return repriced, changes
+}
+
+// unpopularity counts the chore's surge points, and explains them, such as "hated by
+// Alice and Bob, left unassigned 2 of the last 4 weeks".
+func unpopularity(chore models.Chore, people []models.Person, unassigned, weeks int) (points int, reason string) {
+	var hated, loved []string
+	for _, person := range people {
+		switch Rating(person, chore) {
+		case -1:
+			hated = append(hated, person.Name)
+		case 1:
+			loved = append(loved, person.Name)
+		}
+	}
+	points = max(len(hated)-len(loved), 0) + unassigned
+
+	var reasons []string
+	if len(hated) > len(loved) {
+		reasons = append(reasons, "hated by "+joinNames(hated))
+		if len(loved) > 0 {
+			reasons = append(reasons, "loved by "+joinNames(loved))
+		}
+	}
+	if unassigned > 0 {
+		reasons = append(reasons, fmt.Sprintf("left unassigned %d of the last %d weeks", unassigned, weeks))
+	}
+	return points, strings.Join(reasons, ", ")
+}
+
+// joinNames lists names as "Alice", "Alice and Bob" or "Alice, Bob and Carol".
+func joinNames(names []string) string {
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
@@ -0,0 +1,78 @@
+package distributor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+func TestSurge(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Preferences: map[string]string{"Bathroom": models.Hate, "Kitchen": models.Hate}},
+		{Name: "Bob", Preferences: map[string]string{"Bathroom": models.Hate, "Kitchen": models.Love}},
+		{Name: "Carol", Preferences: map[string]string{"Bathroom": models.Hate}},
+	}
+	chores := []models.Chore{
+		{Name: "Bathroom", Earned: 4},
+		{Name: "Kitchen", Earned: 5},
+		{Name: "Garage", Earned: 6},
+		{Name: "Garage", Earned: 6},
+		{Name: "Trash", Earned: 1},
+	}
+	unwanted := map[string]int{"Garage": 1}
+
+	repriced, changes := Surge(chores, people, unwanted, models.Surge{Weeks: 4, Step: 2, Max: 5})
+
+	// Bathroom: 3 hates at $2 each, capped at $5. Kitchen: a hate and a love cancel
+	// out. Garage: left unassigned once, for every instance. Trash: nothing
+	want := []int{9, 5, 8, 8, 1}
+	for i, chore := range repriced {
+		if chore.Earned != want[i] || chore.Surge != want[i]-chores[i].Earned {
+			t.Errorf("Expected %s to earn $%d, got $%d (surge $%d)", chore.Name, want[i], chore.Earned, chore.Surge)
+		}
+	}
+	if chores[0].Earned != 4 {
+		t.Error("Surge should not modify its input")
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("Expected a price change for Bathroom and Garage, got %+v", changes)
+	}
+	if c := changes[0]; c.Chore != "Bathroom" || c.Base != 4 || c.Bonus != 5 || c.Reason != "hated by Alice, Bob and Carol" {
+		t.Errorf("Unexpected change: %+v", c)
+	}
+	if c := changes[1]; c.Chore != "Garage" || c.Bonus != 2 || c.Reason != "left unassigned 1 of the last 4 weeks" {
+		t.Errorf("Unexpected change: %+v", c)
+	}
+}
+
+func TestSurge_TeamShares(t *testing.T) {
+	chore := models.Chore{Name: "Garage", Earned: 9, Surge: 3, Headcount: 2}
+	parts := Shares(chore)
+	if parts[0].Surge+parts[1].Surge != 3 || parts[0].Surge > parts[0].Earned || parts[1].Surge > parts[1].Earned {
+		t.Errorf("Expected the surge split with the earnings, got %+v", parts)
+	}
+}
+
+func TestPrintDistribution_Surge(t *testing.T) {
+	result := &models.DistributionResult{
+		People: []models.Person{
+			{Name: "Alice", Chores: []models.Chore{{Name: "Bathroom", Earned: 6, Surge: 2}}, TotalEarned: 6},
+		},
+		Pricing: []models.PriceChange{{Chore: "Bathroom", Base: 4, Bonus: 2, Reason: "hated by Alice and Bob"}},
+	}
+
+	var buf bytes.Buffer
+	PrintDistribution(&buf, result, PrintOptions{})
+	output := buf.String()
+	for _, want := range []string{
+		"Bathroom (Earns: $6 ($4 + $2 surge))",
+		"Surge Pricing:\n  - Bathroom: $4 + $2 = $6 (hated by Alice and Bob)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
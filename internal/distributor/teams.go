@@ -0,0 +1,250 @@
+package distributor
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+	"github.com/faradayfan/chore-distributor/internal/recurrence"
+)
+
+// TeamSize is how many people the chore takes.
+func TeamSize(chore models.Chore) int {
+	return max(chore.Headcount, 1)
+}
+
+// Shares returns each team member's part of a chore, largest first. An even split
+// divides Earned and Difficulty as equally as whole numbers allow; a full split gives
+// everyone the whole chore.
+func Shares(chore models.Chore) []models.Chore {
+	n := TeamSize(chore)
+	parts := make([]models.Chore, n)
+	for k := range parts {
+		part := chore
+		if chore.Split != models.SplitFull {
+			part.Earned = chore.Earned / n
+			if k < chore.Earned%n {
+				part.Earned++
+			}
+			part.Surge = chore.Surge / n
+			if k < chore.Surge%n {
+				part.Surge++
+			}
+			part.Difficulty = chore.Difficulty / n
+			if k < chore.Difficulty%n {
+				part.Difficulty++
+			}
+		}
+		parts[k] = part
+	}
+	return parts
+}
+
+// neverPaired reports whether two people may not share a team chore.
+func (o Options) neverPaired(a, b string) bool {
+	for _, group := range o.NeverPair {
+		if slices.Contains(group, a) && slices.Contains(group, b) {
+			return true
+		}
+	}
+	return false
+}
+
+// team is a group of people who could take a team chore together: members[k] would
+// take parts[k], all on day.
+type team struct {
+	members []int
+	day     int
+}
+
+// assignTeams hands out the chores that take more than one person before anything
+// else, largest first. Each goes to the team with the lowest combined weighted score
+// (plus rotation penalties) whose members are all eligible, have capacity for their
+// share and a day they can all do it, have no chore it is kept apart from, and include
+// no NeverPair pair, breaking ties at random. The lowest scorer takes the largest
+// share. It returns the chores that take one person, for the strategy to hand out, and
+// the team chores nobody could take. Without team chores it leaves the random source
+// untouched.
+func (o Options) assignTeams(chores []models.Chore, people []models.Person, rng *rand.Rand) (solo []models.Chore, unassigned []models.UnassignedChore) {
+	var teamChores []models.Chore
+	for _, chore := range chores {
+		if TeamSize(chore) > 1 {
+			teamChores = append(teamChores, chore)
+		} else {
+			solo = append(solo, chore)
+		}
+	}
+	if len(teamChores) == 0 {
+		return chores, nil
+	}
+
+	for _, chore := range sortChores(teamChores, rng, o.Weights) {
+		parts := Shares(chore)
+		var candidates []team
+		minCost := math.Inf(1)
+
+		combinations(len(people), len(parts), func(group []int) {
+			t, cost, ok := o.fitTeam(chore, parts, people, group)
+			if !ok {
+				return
+			}
+			if sameScore(cost, minCost) {
+				candidates = append(candidates, t)
+			} else if cost < minCost {
+				minCost = cost
+				candidates = []team{t}
+			}
+		})
+
+		if len(candidates) == 0 {
+			unassigned = append(unassigned, o.newTeamUnassigned(chore, parts, people))
+			continue
+		}
+
+		chosen := candidates[rng.IntN(len(candidates))]
+		var tied []int
+		for _, t := range candidates {
+			for _, i := range t.members {
+				if !slices.Contains(tied, i) {
+					tied = append(tied, i)
+				}
+			}
+		}
+		if len(candidates) == 1 {
+			tied = nil
+		}
+		// Anyone who can't take the smallest share can't be on the team
+		o.decide(parts[len(parts)-1:], people, chosen.members, tied, "lowest combined score of any team that can take it")
+
+		var names []string
+		for _, i := range chosen.members {
+			names = append(names, people[i].Name)
+		}
+		for k, i := range chosen.members {
+			part := parts[k]
+			part.Team = names
+			if chosen.day != noDay {
+				part.Date = o.weekStart().AddDate(0, 0, chosen.day)
+			}
+			o.assign(&people[i], part)
+		}
+	}
+	return solo, unassigned
+}
+
+// fitTeam checks whether the group of people can take the chore together, and if so
+// returns them ordered lowest score first (to match the parts, largest first), the
+// day they would do it, and their combined score.
+func (o Options) fitTeam(chore models.Chore, parts []models.Chore, people []models.Person, group []int) (t team, cost float64, ok bool) {
+	for a := range group {
+		for b := a + 1; b < len(group); b++ {
+			if o.neverPaired(people[group[a]].Name, people[group[b]].Name) {
+				return team{}, 0, false
+			}
+		}
+	}
+
+	members := slices.Clone(group)
+	score := func(i int) float64 {
+		return Score(people[i], o.Weights) + o.rotationCost(people[i], chore)
+	}
+	slices.SortStableFunc(members, func(a, b int) int {
+		switch sa, sb := score(a), score(b); {
+		case sameScore(sa, sb):
+			return 0
+		case sa < sb:
+			return -1
+		}
+		return 1
+	})
+
+	for k, i := range members {
+		if !o.eligible(people[i], parts[k]) || !hasCapacity(people[i], parts[k]) || o.apartFrom(people[i], parts[k]) != "" {
+			return team{}, 0, false
+		}
+		cost += score(i)
+	}
+
+	day, ok := o.pickTeamDay(chore, parts, people, members)
+	if !ok {
+		return team{}, 0, false
+	}
+	return team{members: members, day: day}, cost, true
+}
+
+// pickTeamDay chooses the day a team does a chore together: the allowed day where the
+// member with the least room left afterwards has the most, then the earliest. A chore
+// that is already dated keeps its day. It returns noDay for a chore that is not
+// scheduled on a day, and ok is false if no allowed day has room for every member.
+func (o Options) pickTeamDay(chore models.Chore, parts []models.Chore, people []models.Person, members []int) (day int, ok bool) {
+	if !o.scheduled(chore) {
+		return noDay, true
+	}
+
+	start := o.weekStart()
+	fixed := dayOffset(start, chore.Date)
+	if !chore.Date.IsZero() && fixed == noDay {
+		return noDay, true
+	}
+
+	best, bestRoom := noDay, 0
+	for d := 0; d < 7; d++ {
+		if (fixed != noDay && d != fixed) || !recurrence.AllowedOn(chore, start.AddDate(0, 0, d).Weekday()) {
+			continue
+		}
+		least := math.MaxInt
+		for k, i := range members {
+			least = min(least, o.room(people[i], dayLoads(people[i], start), d)-parts[k].Difficulty)
+		}
+		if least >= 0 && (best == noDay || least > bestRoom) {
+			best, bestRoom = d, least
+		}
+	}
+	return best, best != noDay
+}
+
+// newTeamUnassigned records a team chore no team could take, and why each person who
+// could not be on the team was left out.
+func (o Options) newTeamUnassigned(chore models.Chore, parts []models.Chore, people []models.Person) models.UnassignedChore {
+	// Anyone who can't take even the smallest share can't be on the team
+	smallest := parts[len(parts)-1]
+	var excluded []models.Exclusion
+	for _, person := range people {
+		if reason := o.whyNot(person, smallest); reason != "" {
+			excluded = append(excluded, models.Exclusion{Person: person.Name, Reason: reason})
+		}
+	}
+
+	n := len(parts)
+	available := len(people) - len(excluded)
+	reason := fmt.Sprintf("needs %d people, only %d can take it", n, available)
+	if available >= n {
+		reason = fmt.Sprintf("no team of %d has room for their shares on a common day without a never-pair conflict", n)
+	}
+	return models.UnassignedChore{
+		Chore:    chore,
+		Reason:   reason,
+		Excluded: excluded,
+	}
+}
+
+// combinations calls fn with every way of choosing k of the indexes 0 to n-1, in
+// increasing order. The slice passed to fn is reused between calls.
+func combinations(n, k int, fn func([]int)) {
+	group := make([]int, 0, k)
+	var choose func(from int)
+	choose = func(from int) {
+		if len(group) == k {
+			fn(group)
+			return
+		}
+		for i := from; i <= n-(k-len(group)); i++ {
+			group = append(group, i)
+			choose(i + 1)
+			group = group[:len(group)-1]
+		}
+	}
+	choose(0)
+}
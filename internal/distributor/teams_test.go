@@ -0,0 +1,158 @@
+package distributor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+func TestShares(t *testing.T) {
+	even := Shares(models.Chore{Name: "Garage", Headcount: 3, Earned: 10, Difficulty: 7})
+	if len(even) != 3 {
+		t.Fatalf("Expected 3 shares, got %d", len(even))
+	}
+	wantEarned, wantDifficulty := []int{4, 3, 3}, []int{3, 2, 2}
+	for k, part := range even {
+		if part.Earned != wantEarned[k] || part.Difficulty != wantDifficulty[k] {
+			t.Errorf("Share %d: expected $%d and difficulty %d, got $%d and %d",
+				k, wantEarned[k], wantDifficulty[k], part.Earned, part.Difficulty)
+		}
+	}
+
+	full := Shares(models.Chore{Name: "Garage", Headcount: 2, Split: models.SplitFull, Earned: 10, Difficulty: 7})
+	for _, part := range full {
+		if part.Earned != 10 || part.Difficulty != 7 {
+			t.Errorf("Expected everyone to get the full chore, got %+v", part)
+		}
+	}
+
+	if solo := Shares(models.Chore{Name: "Dishes", Earned: 5}); len(solo) != 1 || solo[0].Earned != 5 {
+		t.Errorf("Expected one share for a chore without a headcount, got %+v", solo)
+	}
+}
+
+func TestAssignTeams(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}, TotalEarned: 20, TotalDifficulty: 20},
+		{Name: "Carol", Chores: []models.Chore{}},
+	}
+	chores := []models.Chore{
+		{Name: "Garage", Headcount: 2, Earned: 9, Difficulty: 6},
+		{Name: "Dishes", Earned: 3, Difficulty: 2},
+	}
+
+	for _, strategy := range []string{"greedy", "round-robin", "optimal"} {
+		s, _ := Lookup(strategy)
+		result := s.Distribute(chores, people, NewRand(1), DefaultOptions())
+		if len(result.Unassigned) != 0 {
+			t.Fatalf("%s: expected everything assigned, got %+v", strategy, result.Unassigned)
+		}
+
+		// Bob is well ahead, so Alice and Carol do the garage together
+		var team []string
+		earned := 0
+		for _, person := range result.People {
+			for _, chore := range person.Chores {
+				if chore.Name == "Garage" {
+					team = append(team, person.Name)
+					earned += chore.Earned
+					if others := chore.Teammates(person.Name); len(others) != 1 {
+						t.Errorf("%s: expected %s to have one teammate, got %v", strategy, person.Name, others)
+					}
+				}
+			}
+		}
+		if strings.Join(team, ",") != "Alice,Carol" || earned != 9 {
+			t.Errorf("%s: expected Alice and Carol to split $9 for the garage, got %v for $%d", strategy, team, earned)
+		}
+	}
+}
+
+func TestAssignTeams_NeverPair(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}},
+		{Name: "Carol", Chores: []models.Chore{}, TotalEarned: 50},
+	}
+	chores := []models.Chore{{Name: "Garage", Headcount: 2, Earned: 10, Difficulty: 4}}
+
+	opts := DefaultOptions()
+	opts.NeverPair = [][]string{{"Alice", "Bob"}}
+	result := distributeGreedy(chores, people, NewRand(1), opts)
+	// Carol is well ahead, but Alice and Bob can't be paired
+	if carol := result.People[2]; len(carol.Chores) != 1 {
+		t.Errorf("Expected Carol on the team, got %+v", carol.Chores)
+	}
+
+	opts.NeverPair = [][]string{{"Alice", "Bob", "Carol"}}
+	result = distributeGreedy(chores, people, NewRand(1), opts)
+	if len(result.Unassigned) != 1 || !strings.Contains(result.Unassigned[0].Reason, "never-pair") {
+		t.Errorf("Expected the garage unassigned because of never-pair, got %+v", result.Unassigned)
+	}
+}
+
+func TestAssignTeams_NotEnoughPeople(t *testing.T) {
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}},
+		{Name: "Bob", Chores: []models.Chore{}, EffortCapacity: 2},
+	}
+	chores := []models.Chore{{Name: "Garage", Headcount: 2, Earned: 10, Difficulty: 8}}
+
+	result := distributeGreedy(chores, people, NewRand(1), DefaultOptions())
+	if len(result.Unassigned) != 1 {
+		t.Fatalf("Expected the garage unassigned, got %+v", result.Unassigned)
+	}
+	u := result.Unassigned[0]
+	if u.Reason != "needs 2 people, only 1 can take it" {
+		t.Errorf("Unexpected reason %q", u.Reason)
+	}
+	if len(u.Excluded) != 1 || u.Excluded[0].Person != "Bob" {
+		t.Errorf("Expected Bob excluded for capacity, got %+v", u.Excluded)
+	}
+	for _, person := range result.People {
+		if len(person.Chores) != 0 {
+			t.Errorf("Expected nobody to take part of an unassigned team chore, got %+v", person)
+		}
+	}
+}
+
+func TestAssignTeams_CommonDay(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Date = planStart
+	opts.WeekPlan = true
+	people := []models.Person{
+		{Name: "Alice", Chores: []models.Chore{}, DayCapacity: map[string]int{"Sat": 0}},
+		{Name: "Bob", Chores: []models.Chore{}, DayCapacity: map[string]int{"Sun": 0}},
+	}
+	chores := []models.Chore{{Name: "Garage", Headcount: 2, Earned: 10, Difficulty: 4, Days: []string{"Sat", "Sun"}}}
+
+	result := distributeGreedy(chores, people, NewRand(1), opts)
+	if len(result.Unassigned) != 1 {
+		t.Errorf("Expected no common day for the garage, got %+v", result.People)
+	}
+
+	chores[0].Days = []string{"Fri", "Sat", "Sun"}
+	result = distributeGreedy(chores, people, NewRand(1), opts)
+	for _, person := range result.People {
+		if len(person.Chores) != 1 || person.Chores[0].Date.Weekday().String() != "Friday" {
+			t.Errorf("Expected %s to do the garage on Friday, got %+v", person.Name, person.Chores)
+		}
+	}
+}
+
+func TestPrintDistribution_Team(t *testing.T) {
+	result := &models.DistributionResult{
+		People: []models.Person{
+			{Name: "Alice", Chores: []models.Chore{{Name: "Garage", Earned: 5, Team: []string{"Alice", "Bob"}}}},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintDistribution(&buf, result, PrintOptions{})
+	if !strings.Contains(buf.String(), "Garage with Bob (Earns: $5)") {
+		t.Errorf("Expected the teammate in the output, got:\n%s", buf.String())
+	}
+}
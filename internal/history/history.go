@@ -0,0 +1,480 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// ChoreRecord is a chore as it was assigned in a saved distribution.
+type ChoreRecord struct {
+	Name        string `json:"name"`
+	Difficulty  int    `json:"difficulty"`
+	Earned      int    `json:"earned"`
+	Description string `json:"description,omitempty"`
+	// Date is the day an instance of a recurring chore was due (YYYY-MM-DD).
+	Date string `json:"date,omitempty"`
+	// Team is everyone who shared a team chore, including this person.
+	Team []string `json:"team,omitempty"`
+	// Surge is the part of Earned that was a surge bonus.
+	Surge int `json:"surge,omitempty"`
+}
+
+// dateLayout is the format of ChoreRecord.Date.
+const dateLayout = "2006-01-02"
+
+// PersonRecord is one person's share of a saved distribution.
+type PersonRecord struct {
+	Name            string        `json:"name"`
+	EffortCapacity  int           `json:"effortCapacity,omitempty"`
+	PreAssigned     []ChoreRecord `json:"preAssigned,omitempty"`
+	Chores          []ChoreRecord `json:"chores"`
+	TotalDifficulty int           `json:"totalDifficulty"`
+	TotalEarned     int           `json:"totalEarned"`
+}
+
+// UnassignedRecord is a chore that could not be assigned in a saved distribution.
+type UnassignedRecord struct {
+	Chore  ChoreRecord `json:"chore"`
+	Reason string      `json:"reason"`
+}
+
+// PriceRecord is a surge bonus given to a chore in a saved distribution, and why.
+type PriceRecord struct {
+	Chore  string `json:"chore"`
+	Base   int    `json:"base"`
+	Bonus  int    `json:"bonus"`
+	Reason string `json:"reason"`
+}
+
+// DecisionRecord explains how chores were handed out in a saved distribution.
+type DecisionRecord struct {
+	Chores     []ChoreRecord         `json:"chores"`
+	People     []string              `json:"people"`
+	Rule       string                `json:"rule"`
+	Considered []ConsiderationRecord `json:"considered"`
+	Tied       []string              `json:"tied,omitempty"`
+}
+
+// ConsiderationRecord is one person as they stood when a decision was made.
+type ConsiderationRecord struct {
+	Person       string  `json:"person"`
+	Earned       int     `json:"earned"`
+	CarryOver    int     `json:"carryOver,omitempty"`
+	CapacityLeft int     `json:"capacityLeft"`
+	Score        float64 `json:"score"`
+	Excluded     string  `json:"excluded,omitempty"`
+}
+
+// AwayRecord is a person who was away for the whole week of a saved distribution.
+type AwayRecord struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// Entry is a single confirmed distribution.
+type Entry struct {
+	ID         int                `json:"id"`
+	Timestamp  time.Time          `json:"timestamp"`
+	Seed       uint64             `json:"seed"`
+	ConfigHash string             `json:"configHash"`
+	Strategy   string             `json:"strategy,omitempty"`
+	People     []PersonRecord     `json:"people"`
+	Away       []AwayRecord       `json:"away,omitempty"`
+	Unassigned []UnassignedRecord `json:"unassigned,omitempty"`
+	Pricing    []PriceRecord      `json:"pricing,omitempty"`
+	Decisions  []DecisionRecord   `json:"decisions,omitempty"`
+	// Planned marks a week saved ahead of time from a multi-week plan, dated the day the
+	// week starts. It hasn't happened yet, so it is left out of the weeks later
+	// distributions look back over.
+	Planned bool `json:"planned,omitempty"`
+}
+
+// Store is an append-only JSON-lines file of distributions, oldest first.
+type Store struct {
+	Path string
+}
+
+func NewStore(path string) *Store {
+	return &Store{Path: path}
+}
+
+// PathFor returns the default history file for a config file: the same name with a
+// .history.jsonl extension, in the same directory.
+func PathFor(configPath string) string {
+	ext := filepath.Ext(configPath)
+	return strings.TrimSuffix(configPath, ext) + ".history.jsonl"
+}
+
+// NewEntry builds a history entry from a distribution result.
+func NewEntry(result *models.DistributionResult, configHash string, timestamp time.Time) Entry {
+	entry := Entry{
+		Timestamp:  timestamp,
+		Seed:       result.Seed,
+		ConfigHash: configHash,
+		Strategy:   result.Strategy,
+	}
+
+	for _, person := range result.People {
+		entry.People = append(entry.People, PersonRecord{
+			Name:            person.Name,
+			EffortCapacity:  person.EffortCapacity,
+			PreAssigned:     toRecords(person.PreAssignedChores),
+			Chores:          toRecords(person.Chores),
+			TotalDifficulty: person.TotalDifficulty,
+			TotalEarned:     person.TotalEarned,
+		})
+	}
+
+	for _, person := range result.Away {
+		entry.Away = append(entry.Away, AwayRecord{Name: person.Name, Reason: person.Away})
+	}
+
+	for _, u := range result.Unassigned {
+		entry.Unassigned = append(entry.Unassigned, UnassignedRecord{
+			Chore:  toRecord(u.Chore),
+			Reason: u.Reason,
+		})
+	}
+
+	for _, p := range result.Pricing {
+		entry.Pricing = append(entry.Pricing, PriceRecord(p))
+	}
+
+	for _, d := range result.Decisions {
+		record := DecisionRecord{
+			Chores: toRecords(d.Chores),
+			People: d.People,
+			Rule:   d.Rule,
+			Tied:   d.Tied,
+		}
+		for _, c := range d.Considered {
+			record.Considered = append(record.Considered, ConsiderationRecord(c))
+		}
+		entry.Decisions = append(entry.Decisions, record)
+	}
+
+	return entry
+}
+
+// Result converts the entry back into a distribution result, e.g. for printing.
+func (e Entry) Result() *models.DistributionResult {
+	result := &models.DistributionResult{
+		Seed:     e.Seed,
+		Strategy: e.Strategy,
+	}
+
+	for _, p := range e.People {
+		result.People = append(result.People, models.Person{
+			Name:              p.Name,
+			EffortCapacity:    p.EffortCapacity,
+			PreAssignedChores: fromRecords(p.PreAssigned),
+			Chores:            fromRecords(p.Chores),
+			TotalDifficulty:   p.TotalDifficulty,
+			TotalEarned:       p.TotalEarned,
+		})
+	}
+
+	for _, a := range e.Away {
+		result.Away = append(result.Away, models.Person{Name: a.Name, Away: a.Reason})
+	}
+
+	for _, u := range e.Unassigned {
+		result.Unassigned = append(result.Unassigned, models.UnassignedChore{
+			Chore:  fromRecord(u.Chore),
+			Reason: u.Reason,
+		})
+	}
+
+	for _, p := range e.Pricing {
+		result.Pricing = append(result.Pricing, models.PriceChange(p))
+	}
+
+	for _, d := range e.Decisions {
+		decision := models.Decision{
+			Chores: fromRecords(d.Chores),
+			People: d.People,
+			Rule:   d.Rule,
+			Tied:   d.Tied,
+		}
+		for _, c := range d.Considered {
+			decision.Considered = append(decision.Considered, models.Consideration(c))
+		}
+		result.Decisions = append(result.Decisions, decision)
+	}
+
+	return result
+}
+
+// Load returns every entry in the store, oldest first. A missing file is an empty history.
+func (s *Store) Load() ([]Entry, error) {
+	file, err := os.Open(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening history: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal([]byte(text), &entry); err != nil {
+			return nil, fmt.Errorf("error parsing history line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading history: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Get returns the entry with the given ID.
+func (s *Store) Get(id int) (Entry, error) {
+	entries, err := s.Load()
+	if err != nil {
+		return Entry{}, err
+	}
+	for _, entry := range entries {
+		if entry.ID == id {
+			return entry, nil
+		}
+	}
+	return Entry{}, fmt.Errorf("no distribution with ID %d in %s", id, s.Path)
+}
+
+// Latest returns the most recent distribution that was actually made: the last entry
+// that isn't planned. ok is false if there isn't one.
+func Latest(entries []Entry) (entry Entry, ok bool) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if !entries[i].Planned {
+			return entries[i], true
+		}
+	}
+	return Entry{}, false
+}
+
+// Append assigns the entry the next ID and adds it to the end of the store.
+func (s *Store) Append(entry Entry) (Entry, error) {
+	entries, err := s.Load()
+	if err != nil {
+		return Entry{}, err
+	}
+
+	entry.ID = 1
+	if len(entries) > 0 {
+		entry.ID = entries[len(entries)-1].ID + 1
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return Entry{}, fmt.Errorf("error encoding history entry: %w", err)
+	}
+
+	file, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return Entry{}, fmt.Errorf("error opening history: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return Entry{}, fmt.Errorf("error writing history: %w", err)
+	}
+
+	return entry, nil
+}
+
+// weekOf returns the start of the week t falls in: midnight on the Monday on or before it.
+func weekOf(t time.Time) time.Time {
+	monday := t.AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+	return time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// lastWeeks returns the entries from the n weeks before the week of date, oldest
+// first. Weeks run Monday to Sunday, and only the latest entry in each week is kept, so
+// a distribution re-run in the same week replaces the earlier one. A week without an
+// entry still counts toward the n. Planned entries are skipped. n <= 0 uses every week
+// before date.
+func lastWeeks(entries []Entry, date time.Time, n int) []Entry {
+	current := weekOf(date)
+	from := current.AddDate(0, 0, -7*n)
+
+	latest := make(map[time.Time]Entry)
+	for _, entry := range entries {
+		if entry.Planned {
+			continue
+		}
+		week := weekOf(entry.Timestamp.In(date.Location()))
+		if !week.Before(current) || (n > 0 && week.Before(from)) {
+			continue
+		}
+		if kept, ok := latest[week]; !ok || !entry.Timestamp.Before(kept.Timestamp) {
+			latest[week] = entry
+		}
+	}
+
+	weeks := make([]Entry, 0, len(latest))
+	for _, entry := range latest {
+		weeks = append(weeks, entry)
+	}
+	slices.SortFunc(weeks, func(a, b Entry) int { return a.Timestamp.Compare(b.Timestamp) })
+	return weeks
+}
+
+// RecentChores counts how many times each person had each distributed chore in the n
+// weeks before the week of date, keyed by person name and then chore name. Pre-assigned
+// chores are not counted since they never rotate.
+func RecentChores(entries []Entry, date time.Time, n int) map[string]map[string]int {
+	recent := make(map[string]map[string]int)
+	if n <= 0 {
+		return recent
+	}
+
+	for _, entry := range lastWeeks(entries, date, n) {
+		for _, p := range entry.People {
+			for _, chore := range p.Chores {
+				if recent[p.Name] == nil {
+					recent[p.Name] = make(map[string]int)
+				}
+				recent[p.Name][chore.Name]++
+			}
+		}
+	}
+	return recent
+}
+
+// CarryOver returns each person's cumulative earnings surplus (positive) or deficit
+// (negative) over the n weeks before the week of date, keyed by person name. Each week
+// a person's surplus is what they earned minus that week's average across everyone; a
+// week distributed more than once counts once, as its last distribution.
+// n <= 0 uses the whole history.
+func CarryOver(entries []Entry, date time.Time, n int) map[string]int {
+	surplus := make(map[string]float64)
+	for _, entry := range lastWeeks(entries, date, n) {
+		if len(entry.People) == 0 {
+			continue
+		}
+
+		total := 0
+		for _, p := range entry.People {
+			total += p.TotalEarned
+		}
+		average := float64(total) / float64(len(entry.People))
+
+		for _, p := range entry.People {
+			surplus[p.Name] += float64(p.TotalEarned) - average
+		}
+	}
+
+	carry := make(map[string]int)
+	for name, amount := range surplus {
+		carry[name] = int(math.Round(amount))
+	}
+	return carry
+}
+
+// UnassignedWeeks counts how many of the n weeks before the week of date left each
+// chore, by name, unassigned. Instances of a recurring chore count once a week, and a
+// week distributed more than once counts as its last distribution.
+func UnassignedWeeks(entries []Entry, date time.Time, n int) map[string]int {
+	weeks := make(map[string]int)
+	if n <= 0 {
+		return weeks
+	}
+
+	for _, entry := range lastWeeks(entries, date, n) {
+		seen := make(map[string]bool)
+		for _, u := range entry.Unassigned {
+			if !seen[u.Chore.Name] {
+				seen[u.Chore.Name] = true
+				weeks[u.Chore.Name]++
+			}
+		}
+	}
+	return weeks
+}
+
+// LastDistributed returns when each chore (by name) was last given to someone before the
+// week of date, so recurring chores that are not due yet can be skipped. Like the other
+// lookbacks it only counts the last distribution of each week and skips planned
+// entries, so re-running a week doesn't make its own chores look done already.
+func LastDistributed(entries []Entry, date time.Time) map[string]time.Time {
+	last := make(map[string]time.Time)
+	for _, entry := range lastWeeks(entries, date, 0) {
+		for _, p := range entry.People {
+			for _, chore := range p.Chores {
+				if entry.Timestamp.After(last[chore.Name]) {
+					last[chore.Name] = entry.Timestamp
+				}
+			}
+		}
+	}
+	return last
+}
+
+func toRecord(chore models.Chore) ChoreRecord {
+	record := ChoreRecord{
+		Name:        chore.Name,
+		Difficulty:  chore.Difficulty,
+		Earned:      chore.Earned,
+		Description: chore.Description,
+		Team:        chore.Team,
+		Surge:       chore.Surge,
+	}
+	if !chore.Date.IsZero() {
+		record.Date = chore.Date.Format(dateLayout)
+	}
+	return record
+}
+
+func toRecords(chores []models.Chore) []ChoreRecord {
+	records := []ChoreRecord{}
+	for _, chore := range chores {
+		records = append(records, toRecord(chore))
+	}
+	return records
+}
+
+func fromRecord(record ChoreRecord) models.Chore {
+	chore := models.Chore{
+		Name:        record.Name,
+		Difficulty:  record.Difficulty,
+		Earned:      record.Earned,
+		Description: record.Description,
+		Team:        record.Team,
+		Surge:       record.Surge,
+	}
+	if record.Date != "" {
+		chore.Date, _ = time.ParseInLocation(dateLayout, record.Date, time.Local)
+	}
+	return chore
+}
+
+func fromRecords(records []ChoreRecord) []models.Chore {
+	chores := []models.Chore{}
+	for _, record := range records {
+		chores = append(chores, fromRecord(record))
+	}
+	return chores
+}
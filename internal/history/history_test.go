@@ -0,0 +1,435 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+	"github.com/faradayfan/chore-distributor/internal/recurrence"
+)
+
+func sampleResult() *models.DistributionResult {
+	return &models.DistributionResult{
+		People: []models.Person{
+			{
+				Name:              "Alice",
+				EffortCapacity:    10,
+				PreAssignedChores: []models.Chore{{Name: "Bedroom", Difficulty: 1, Earned: 1}},
+				Chores:            []models.Chore{{Name: "Kitchen", Difficulty: 6, Earned: 5, Description: "Counters"}},
+				TotalDifficulty:   7,
+				TotalEarned:       6,
+			},
+			{
+				Name: "Bob",
+				Chores: []models.Chore{
+					{Name: "Bathroom", Difficulty: 5, Earned: 4},
+					{Name: "Dishes", Difficulty: 1, Earned: 1, Date: time.Date(2025, time.June, 3, 0, 0, 0, 0, time.Local)},
+				},
+				TotalDifficulty: 6,
+				TotalEarned:     5,
+			},
+		},
+		Away: []models.Person{{Name: "Carol", Away: "camp"}},
+		Unassigned: []models.UnassignedChore{
+			{Chore: models.Chore{Name: "Garage", Difficulty: 12, Earned: 8}, Reason: "no one has capacity"},
+		},
+		Decisions: []models.Decision{{
+			Chores: []models.Chore{{Name: "Bathroom", Difficulty: 5, Earned: 4}},
+			People: []string{"Bob"},
+			Rule:   "lowest earnings so far",
+			Considered: []models.Consideration{
+				{Person: "Alice", Earned: 5, CapacityLeft: 4, Score: 5, Excluded: "only 4 of 10 effort capacity left, chore needs 5"},
+				{Person: "Bob", CapacityLeft: -1},
+			},
+		}},
+		Seed:     42,
+		Strategy: "greedy",
+	}
+}
+
+func TestPathFor(t *testing.T) {
+	tests := map[string]string{
+		"chores_config.json":        "chores_config.history.jsonl",
+		"/home/me/family.json":      "/home/me/family.history.jsonl",
+		filepath.Join("dir", "cfg"): filepath.Join("dir", "cfg.history.jsonl"),
+	}
+	for configPath, want := range tests {
+		if got := PathFor(configPath); got != want {
+			t.Errorf("PathFor(%q) = %q, want %q", configPath, got, want)
+		}
+	}
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "missing.jsonl"))
+	entries, err := store.Load()
+	if err != nil {
+		t.Fatalf("Expected no error for a missing history file, got %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected empty history, got %d entries", len(entries))
+	}
+}
+
+func TestAppend_AssignsSequentialIDs(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "history.jsonl"))
+	now := time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	for want := 1; want <= 3; want++ {
+		entry, err := store.Append(NewEntry(sampleResult(), "abc123", now))
+		if err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+		if entry.ID != want {
+			t.Errorf("Expected ID %d, got %d", want, entry.ID)
+		}
+	}
+
+	entries, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	if !entries[0].Timestamp.Equal(now) || entries[0].ConfigHash != "abc123" || entries[0].Seed != 42 {
+		t.Errorf("Entry fields not preserved: %+v", entries[0])
+	}
+}
+
+func TestGet(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "history.jsonl"))
+	if _, err := store.Append(NewEntry(sampleResult(), "abc123", time.Now())); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := store.Get(1)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if entry.Strategy != "greedy" {
+		t.Errorf("Expected strategy greedy, got %q", entry.Strategy)
+	}
+
+	if _, err := store.Get(2); err == nil {
+		t.Error("Expected an error for a missing ID")
+	}
+}
+
+func TestEntry_ResultRoundTrip(t *testing.T) {
+	original := sampleResult()
+	result := NewEntry(original, "abc123", time.Now()).Result()
+
+	if result.Seed != 42 || result.Strategy != "greedy" {
+		t.Errorf("Seed or strategy not preserved: %d %q", result.Seed, result.Strategy)
+	}
+	if len(result.People) != 2 {
+		t.Fatalf("Expected 2 people, got %d", len(result.People))
+	}
+
+	alice := result.People[0]
+	if alice.TotalEarned != 6 || alice.TotalDifficulty != 7 || alice.EffortCapacity != 10 {
+		t.Errorf("Alice totals not preserved: %+v", alice)
+	}
+	if len(alice.PreAssignedChores) != 1 || alice.PreAssignedChores[0].Name != "Bedroom" {
+		t.Errorf("Pre-assigned chores not preserved: %+v", alice.PreAssignedChores)
+	}
+	if len(alice.Chores) != 1 || alice.Chores[0].Description != "Counters" {
+		t.Errorf("Chores not preserved: %+v", alice.Chores)
+	}
+
+	if dishes := result.People[1].Chores[1]; dishes.Label() != "Dishes (Tue Jun 3)" {
+		t.Errorf("Recurring chore date not preserved: %q", dishes.Label())
+	}
+
+	if len(result.Away) != 1 || result.Away[0].Name != "Carol" || result.Away[0].Away != "camp" {
+		t.Errorf("Away people not preserved: %+v", result.Away)
+	}
+
+	if len(result.Unassigned) != 1 || result.Unassigned[0].Chore.Name != "Garage" {
+		t.Errorf("Unassigned chores not preserved: %+v", result.Unassigned)
+	}
+
+	if len(result.Decisions) != 1 || len(result.Decisions[0].Considered) != 2 ||
+		result.Decisions[0].Considered[0].Excluded != original.Decisions[0].Considered[0].Excluded {
+		t.Errorf("Decisions not preserved: %+v", result.Decisions)
+	}
+}
+
+func TestLoad_InvalidLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	if err := os.WriteFile(path, []byte("{not json}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewStore(path).Load(); err == nil {
+		t.Error("Expected an error for a corrupt history line")
+	}
+}
+
+func TestLatest(t *testing.T) {
+	if _, ok := Latest(nil); ok {
+		t.Error("Expected no latest entry in an empty history")
+	}
+
+	entries := []Entry{
+		{ID: 1},
+		{ID: 2},
+		{ID: 3, Planned: true},
+		{ID: 4, Planned: true},
+	}
+	if latest, ok := Latest(entries); !ok || latest.ID != 2 {
+		t.Errorf("Expected #2, the last week not planned ahead, got #%d", latest.ID)
+	}
+	if _, ok := Latest(entries[2:]); ok {
+		t.Error("Expected no latest entry when every week is planned")
+	}
+}
+
+func TestRecentChores(t *testing.T) {
+	// Sundays, a week apart
+	week1 := time.Date(2025, time.June, 1, 9, 0, 0, 0, time.UTC)
+	week2 := week1.AddDate(0, 0, 7)
+	week3 := week1.AddDate(0, 0, 14)
+	entry := func(timestamp time.Time, alice, bob string) Entry {
+		return Entry{Timestamp: timestamp, People: []PersonRecord{
+			{Name: "Alice", PreAssigned: []ChoreRecord{{Name: "Bedroom"}}, Chores: []ChoreRecord{{Name: alice}}},
+			{Name: "Bob", Chores: []ChoreRecord{{Name: bob}}},
+		}}
+	}
+	entries := []Entry{
+		entry(week1, "Kitchen", "Bathroom"),
+		entry(week2, "Bathroom", "Kitchen"),
+		entry(week3, "Bathroom", "Kitchen"),
+	}
+	next := week3.AddDate(0, 0, 7)
+
+	recent := RecentChores(entries, next, 2)
+
+	if recent["Alice"]["Bathroom"] != 2 {
+		t.Errorf("Expected Alice to have had Bathroom twice in the last 2 weeks, got %d", recent["Alice"]["Bathroom"])
+	}
+	if recent["Alice"]["Kitchen"] != 0 {
+		t.Errorf("Kitchen three weeks ago should be outside the window, got %d", recent["Alice"]["Kitchen"])
+	}
+	if recent["Alice"]["Bedroom"] != 0 {
+		t.Error("Pre-assigned chores should not count toward rotation")
+	}
+	if recent["Bob"]["Kitchen"] != 2 {
+		t.Errorf("Expected Bob to have had Kitchen twice, got %d", recent["Bob"]["Kitchen"])
+	}
+
+	if len(RecentChores(entries, next, 0)) != 0 {
+		t.Error("A zero-week window should count nothing")
+	}
+
+	// A week without a distribution still counts toward the window
+	if skipped := RecentChores(entries, next.AddDate(0, 0, 7), 2); skipped["Alice"]["Bathroom"] != 1 {
+		t.Errorf("Expected only week 3 in the window after a skipped week, got %v", skipped)
+	}
+}
+
+func TestRecentChores_SameWeek(t *testing.T) {
+	sunday := time.Date(2025, time.June, 8, 9, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		{Timestamp: sunday.AddDate(0, 0, -7), People: []PersonRecord{{Name: "Alice", Chores: []ChoreRecord{{Name: "Trash"}}}}},
+		// Distributed on Monday, then re-run on Sunday of the same week
+		{Timestamp: sunday.AddDate(0, 0, -6), People: []PersonRecord{{Name: "Alice", Chores: []ChoreRecord{{Name: "Kitchen"}}}}},
+		{Timestamp: sunday, People: []PersonRecord{{Name: "Alice", Chores: []ChoreRecord{{Name: "Bathroom"}}}}},
+	}
+
+	recent := RecentChores(entries, sunday.AddDate(0, 0, 1), 2)
+	if recent["Alice"]["Bathroom"] != 1 || recent["Alice"]["Kitchen"] != 0 {
+		t.Errorf("Expected the re-run to replace the earlier run that week, got %v", recent["Alice"])
+	}
+	if recent["Alice"]["Trash"] != 1 {
+		t.Errorf("Expected the week before to count as the second week, got %v", recent["Alice"])
+	}
+
+	// A run this week isn't a past week
+	if thisWeek := RecentChores(entries, sunday, 1); thisWeek["Alice"]["Trash"] != 1 || len(thisWeek["Alice"]) != 1 {
+		t.Errorf("Expected only last week's chores, got %v", thisWeek["Alice"])
+	}
+}
+
+func TestUnassignedWeeks(t *testing.T) {
+	week1 := time.Date(2025, time.June, 1, 9, 0, 0, 0, time.UTC)
+	week3 := week1.AddDate(0, 0, 14)
+	entries := []Entry{
+		{Timestamp: week1, Unassigned: []UnassignedRecord{{Chore: ChoreRecord{Name: "Garage"}}}},
+		{Timestamp: week1.AddDate(0, 0, 7), Unassigned: []UnassignedRecord{{Chore: ChoreRecord{Name: "Windows"}}}},
+		{Timestamp: week3, Unassigned: []UnassignedRecord{
+			{Chore: ChoreRecord{Name: "Windows", Date: "2025-06-02"}},
+			{Chore: ChoreRecord{Name: "Windows", Date: "2025-06-03"}},
+		}},
+	}
+	next := week3.AddDate(0, 0, 7)
+
+	weeks := UnassignedWeeks(entries, next, 2)
+	if weeks["Windows"] != 2 {
+		t.Errorf("Expected Windows unassigned in 2 weeks, got %d", weeks["Windows"])
+	}
+	if weeks["Garage"] != 0 {
+		t.Errorf("Garage three weeks ago should be outside the window, got %d", weeks["Garage"])
+	}
+	if len(UnassignedWeeks(entries, next, 0)) != 0 {
+		t.Error("A zero-week window should count nothing")
+	}
+
+	// Re-running week 3 doesn't add another week
+	rerun := Entry{Timestamp: week3.Add(time.Hour), Unassigned: []UnassignedRecord{{Chore: ChoreRecord{Name: "Windows"}}}}
+	if weeks := UnassignedWeeks(append(entries, rerun), next, 2); weeks["Windows"] != 2 {
+		t.Errorf("Expected a re-run week to count once, got %d", weeks["Windows"])
+	}
+}
+
+func TestLastDistributed(t *testing.T) {
+	week1 := time.Date(2025, time.June, 1, 9, 0, 0, 0, time.UTC)
+	week2 := week1.AddDate(0, 0, 7)
+	entries := []Entry{
+		{Timestamp: week1, People: []PersonRecord{{Name: "Alice", Chores: []ChoreRecord{{Name: "Windows"}, {Name: "Fridge"}}}}},
+		{Timestamp: week2, People: []PersonRecord{{Name: "Bob", Chores: []ChoreRecord{{Name: "Fridge"}}}},
+			Unassigned: []UnassignedRecord{{Chore: ChoreRecord{Name: "Windows"}}}},
+	}
+
+	last := LastDistributed(entries, week2.AddDate(0, 0, 7))
+	if !last["Windows"].Equal(week1) {
+		t.Errorf("Expected Windows last distributed in week 1 (unassigned doesn't count), got %v", last["Windows"])
+	}
+	if !last["Fridge"].Equal(week2) {
+		t.Errorf("Expected Fridge last distributed in week 2, got %v", last["Fridge"])
+	}
+	if !last["Garage"].IsZero() {
+		t.Error("Expected no date for a chore never distributed")
+	}
+}
+
+func TestLastDistributed_SameWeek(t *testing.T) {
+	windows := models.Chore{Name: "Windows", Frequency: "biweekly", Earned: 3}
+	dishes := models.Chore{Name: "Dishes", Earned: 1}
+	sunday := time.Date(2025, time.June, 8, 9, 0, 0, 0, time.UTC)
+
+	// Windows is due and recorded this week
+	due, err := recurrence.Expand([]models.Chore{windows, dishes}, sunday, LastDistributed(nil, sunday))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 2 {
+		t.Fatalf("Expected Windows and Dishes due, got %v", due)
+	}
+	entries := []Entry{{Timestamp: sunday, People: []PersonRecord{
+		{Name: "Alice", Chores: []ChoreRecord{{Name: "Windows"}, {Name: "Dishes"}}},
+	}}}
+
+	// Running the same week again still has Windows due
+	rerun := sunday.Add(time.Hour)
+	due, err = recurrence.Expand([]models.Chore{windows, dishes}, rerun, LastDistributed(entries, rerun))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 2 {
+		t.Errorf("Expected Windows still due when the week is re-run, got %v", due)
+	}
+
+	// The week after, it isn't
+	next := sunday.AddDate(0, 0, 7)
+	due, err = recurrence.Expand([]models.Chore{windows, dishes}, next, LastDistributed(entries, next))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 1 || due[0].Name != "Dishes" {
+		t.Errorf("Expected only Dishes due the week after, got %v", due)
+	}
+}
+
+func TestCarryOver(t *testing.T) {
+	week1 := time.Date(2025, time.June, 1, 9, 0, 0, 0, time.UTC)
+	week := func(k, jeff, john, tommy int) Entry {
+		return Entry{Timestamp: week1.AddDate(0, 0, 7*k), People: []PersonRecord{
+			{Name: "Jeff", TotalEarned: jeff},
+			{Name: "John", TotalEarned: john},
+			{Name: "Tommy", TotalEarned: tommy},
+		}}
+	}
+	entries := []Entry{
+		week(0, 10, 0, 2),
+		week(1, 6, 9, 3),
+		week(2, 8, 5, 5),
+	}
+	next := week1.AddDate(0, 0, 21)
+
+	carry := CarryOver(entries, next, 2)
+	// Week 2 average is 6, week 3 average is 6.
+	if carry["Jeff"] != 2 || carry["John"] != 2 || carry["Tommy"] != -4 {
+		t.Errorf("Unexpected carry-over over 2 weeks: %v", carry)
+	}
+
+	all := CarryOver(entries, next, 0)
+	// Week 1 average is 4.
+	if all["Jeff"] != 8 || all["John"] != -2 || all["Tommy"] != -6 {
+		t.Errorf("Unexpected carry-over over all weeks: %v", all)
+	}
+
+	// Re-running week 3 replaces it rather than counting it twice
+	rerun := week(2, 6, 6, 6)
+	rerun.Timestamp = rerun.Timestamp.Add(time.Hour)
+	carry = CarryOver(append(entries, rerun), next, 2)
+	if carry["Jeff"] != 0 || carry["John"] != 3 || carry["Tommy"] != -3 {
+		t.Errorf("Unexpected carry-over with week 3 re-run: %v", carry)
+	}
+}
+
+func TestPlannedEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	store := NewStore(path)
+
+	week1 := time.Date(2025, time.June, 1, 9, 0, 0, 0, time.UTC)
+	week2 := week1.AddDate(0, 0, 7)
+	week3 := week1.AddDate(0, 0, 14)
+	entry := func(timestamp time.Time, name, chore string, planned bool) Entry {
+		return Entry{Timestamp: timestamp, Planned: planned, People: []PersonRecord{
+			{Name: name, Chores: []ChoreRecord{{Name: chore}}, TotalEarned: 5},
+		}}
+	}
+
+	// Windows was done in week 1 and Kitchen in week 2, then weeks 2 and 3 were planned
+	// ahead, dated the day each starts
+	for _, e := range []Entry{
+		entry(week1, "Alice", "Windows", false),
+		entry(week2, "Alice", "Kitchen", false),
+		entry(week2.Add(time.Hour), "Bob", "Kitchen", true),
+		entry(week3, "Bob", "Windows", true),
+	} {
+		if _, err := store.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !entries[2].Planned || entries[1].Planned {
+		t.Fatalf("Expected only the planned weeks marked planned, got %+v", entries)
+	}
+
+	// The next real distribution, in week 3, only looks back over what happened
+	recent := RecentChores(entries, week3, 2)
+	if recent["Alice"]["Kitchen"] != 1 || len(recent["Bob"]) != 0 {
+		t.Errorf("Expected rotation to ignore the planned weeks, got %v", recent)
+	}
+	if carry := CarryOver(entries, week3, 0); carry["Bob"] != 0 {
+		t.Errorf("Expected carry-over to ignore the planned weeks, got %v", carry)
+	}
+
+	// Windows is biweekly, so it's due again in week 3 even though the plan has it then
+	windows := models.Chore{Name: "Windows", Frequency: "biweekly", Earned: 3}
+	due, err := recurrence.Expand([]models.Chore{windows}, week3, LastDistributed(entries, week3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 1 {
+		t.Errorf("Expected Windows due in week 3, got %v", due)
+	}
+}
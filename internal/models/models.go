@@ -1,10 +1,112 @@
 package models
 
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
 type Chore struct {
 	Name        string `json:"Name"`
 	Difficulty  int    `json:"Difficulty"`
 	Earned      int    `json:"Earned"`
 	Description string `json:"Description,omitempty"`
+	// AllowedPeople, if set, limits the chore to the named people.
+	AllowedPeople []string `json:"AllowedPeople,omitempty"`
+	// DeniedPeople never get the chore.
+	DeniedPeople []string `json:"DeniedPeople,omitempty"`
+	// MinAge is the youngest a person can be to take the chore. People with no Age or
+	// Birthdate set are not given chores that have a minimum age.
+	MinAge int `json:"MinAge,omitempty"`
+	// Skills lists the skill tags a person needs all of to take the chore.
+	Skills []string `json:"Skills,omitempty"`
+	// Frequency makes the chore recurring: daily, weekdays, weekly, biweekly,
+	// monthly, or "N/week". See the recurrence package.
+	Frequency string `json:"Frequency,omitempty"`
+	// Days limits the chore to these days of the week ("Tue", "Saturday", ...).
+	Days []string `json:"Days,omitempty"`
+	// Headcount is how many people the chore takes (1 if unset). Split says how a
+	// team shares Earned and Difficulty: "even" (the default) divides them, "full"
+	// gives every member the whole amount.
+	Headcount int    `json:"Headcount,omitempty"`
+	Split     string `json:"Split,omitempty"`
+	// Team lists everyone a team chore went to, including the person holding this
+	// share of it.
+	Team []string `json:"-"`
+	// Surge is the bonus added to Earned this week because nobody wants the chore.
+	// Earned includes it.
+	Surge int `json:"-"`
+	// Date is the day the chore is scheduled for: the day a recurring chore's instance
+	// is due, or the day picked for it in a weekly plan. Zero for any day this week.
+	Date time.Time `json:"-"`
+}
+
+// DayChores is the chores scheduled on one day of a weekly plan.
+type DayChores struct {
+	Date   time.Time
+	Chores []Chore
+}
+
+// ByDay splits chores into those not scheduled on a particular day and those scheduled
+// on each day, in date order.
+func ByDay(chores []Chore) (anyDay []Chore, days []DayChores) {
+	for _, chore := range chores {
+		if chore.Date.IsZero() {
+			anyDay = append(anyDay, chore)
+			continue
+		}
+		i := sort.Search(len(days), func(i int) bool { return !days[i].Date.Before(chore.Date) })
+		if i == len(days) || !days[i].Date.Equal(chore.Date) {
+			days = slices.Insert(days, i, DayChores{Date: chore.Date})
+		}
+		days[i].Chores = append(days[i].Chores, chore)
+	}
+	return anyDay, days
+}
+
+// Split rules for team chores.
+const (
+	SplitEven = "even"
+	SplitFull = "full"
+)
+
+// Teammates returns the other people sharing a team chore with person.
+func (c Chore) Teammates(person string) []string {
+	var others []string
+	for _, name := range c.Team {
+		if name != person {
+			others = append(others, name)
+		}
+	}
+	return others
+}
+
+// NameFor is the chore's name as person sees it: for a team chore, followed by who
+// they do it with.
+func (c Chore) NameFor(person string) string {
+	if others := c.Teammates(person); len(others) > 0 {
+		return c.Name + " with " + strings.Join(others, ", ")
+	}
+	return c.Name
+}
+
+// Price is what the chore earns, such as "$7", or "$7 ($5 + $2 surge)" if it has a
+// surge bonus.
+func (c Chore) Price() string {
+	if c.Surge == 0 {
+		return fmt.Sprintf("$%d", c.Earned)
+	}
+	return fmt.Sprintf("$%d ($%d + $%d surge)", c.Earned, c.Earned-c.Surge, c.Surge)
+}
+
+// Label is the chore's name, followed by its day if it is scheduled on one.
+func (c Chore) Label() string {
+	if c.Date.IsZero() {
+		return c.Name
+	}
+	return c.Name + " (" + c.Date.Format("Mon Jan 2") + ")"
 }
 
 type Person struct {
@@ -12,14 +114,257 @@ type Person struct {
 	Contact           string  `json:"Contact,omitempty"`
 	EffortCapacity    int     `json:"EffortCapacity"`
 	PreAssignedChores []Chore `json:"PreAssignedChores,omitempty"`
-	Chores            []Chore `json:"-"`
-	TotalDifficulty   int     `json:"-"`
-	TotalEarned       int     `json:"-"`
+	// Birthdate (YYYY-MM-DD) or Age is checked against a chore's MinAge. Birthdate
+	// takes precedence so the age stays current without editing the config.
+	Birthdate string   `json:"Birthdate,omitempty"`
+	Age       int      `json:"Age,omitempty"`
+	Skills    []string `json:"Skills,omitempty"`
+	// DailyCapacity is the most total difficulty they can be scheduled for on any one
+	// day (0 for no limit). DayCapacity overrides it for particular days of the week,
+	// e.g. {"Sat": 10}; a day set to 0 gets no scheduled chores.
+	DailyCapacity int            `json:"DailyCapacity,omitempty"`
+	DayCapacity   map[string]int `json:"DayCapacity,omitempty"`
+	// Availability describes when they are away. See the availability package.
+	Availability *Availability `json:"Availability,omitempty"`
+	// Calendars are iCalendar (.ics) files whose busy time reduces their capacity.
+	Calendars []string `json:"Calendars,omitempty"`
+	// Preferences rates chores by name as "love", "ok" or "hate". Unrated chores are ok.
+	Preferences     map[string]string `json:"Preferences,omitempty"`
+	Chores          []Chore           `json:"-"`
+	TotalDifficulty int               `json:"-"`
+	TotalEarned     int               `json:"-"`
+	// CarryOver is how far ahead (positive) or behind (negative) of everyone else the
+	// person's earnings were in previous weeks. It counts toward balancing only.
+	CarryOver int `json:"-"`
+	// DaysOff are the days of the week being distributed that they are unavailable.
+	DaysOff []DayOff `json:"-"`
+	// Away is why they are unavailable for the whole week, or "" if they are around.
+	Away string `json:"-"`
+	// Busy is the time their calendars have them busy on each day of the week, and
+	// CalendarLost is the EffortCapacity that busy time cost them.
+	Busy         []BusyDay `json:"-"`
+	CalendarLost int       `json:"-"`
+}
+
+// Ratings a person can give a chore in their Preferences.
+const (
+	Love = "love"
+	OK   = "ok"
+	Hate = "hate"
+)
+
+// Availability lists when a person can't do chores. A day covered by any rule is a
+// day off.
+type Availability struct {
+	// Away lists stretches of days away, such as camp or a trip.
+	Away []Absence `json:"Away,omitempty"`
+	// UnavailableDays are days of the week they never do chores ("Wed", "Sunday", ...).
+	UnavailableDays []string `json:"UnavailableDays,omitempty"`
+	// Alternating is a repeating pattern of weeks with and weeks away, such as
+	// alternating weeks between two households.
+	Alternating *Alternating `json:"Alternating,omitempty"`
+}
+
+// Absence is a range of days away, From and To inclusive (YYYY-MM-DD).
+type Absence struct {
+	From   string `json:"From"`
+	To     string `json:"To"`
+	Reason string `json:"Reason,omitempty"`
+}
+
+// Alternating is WeeksOn weeks around followed by WeeksOff weeks away (1 each by
+// default), repeating from Start (YYYY-MM-DD), the first day of a week around.
+type Alternating struct {
+	Start    string `json:"Start"`
+	WeeksOn  int    `json:"WeeksOn,omitempty"`
+	WeeksOff int    `json:"WeeksOff,omitempty"`
+	Reason   string `json:"Reason,omitempty"`
+}
+
+// BusyDay is the time a person's calendars have them busy on one day. Lost is how
+// much of that day's capacity it cost them; Full means they are busy the whole day
+// and get no chores scheduled on it.
+type BusyDay struct {
+	Date   time.Time
+	Busy   time.Duration
+	Events []string
+	Lost   int
+	Full   bool
+}
+
+// DayOff is a day a person is unavailable, and why.
+type DayOff struct {
+	Date   time.Time
+	Reason string
 }
 
 type Config struct {
-	Chores            []Chore `json:"chores"`
-	People            []Person `json:"people"`
-	SMSTemplatePath   string  `json:"smsTemplatePath,omitempty"`
-	NotesTemplatePath string  `json:"notesTemplatePath,omitempty"`
+	Chores            []Chore    `json:"chores"`
+	People            []Person   `json:"people"`
+	SMSTemplatePath   string     `json:"smsTemplatePath,omitempty"`
+	NotesTemplatePath string     `json:"notesTemplatePath,omitempty"`
+	ParentContact     string     `json:"parentContact,omitempty"`
+	Strategy          string     `json:"strategy,omitempty"`
+	Weights           *Weights   `json:"weights,omitempty"`
+	Rotation          *Rotation  `json:"rotation,omitempty"`
+	CarryOver         *CarryOver `json:"carryOver,omitempty"`
+	HistoryPath       string     `json:"historyPath,omitempty"`
+	// WeekPlan schedules every chore on a day of the week, not just chores with Days
+	// or a daily, weekday or N/week Frequency.
+	WeekPlan bool `json:"weekPlan,omitempty"`
+	// CalendarHours is the part of each day chores get done in. Only busy calendar
+	// time inside it reduces capacity.
+	CalendarHours *CalendarHours `json:"calendarHours,omitempty"`
+	// NeverPair lists groups of people who are never put on the same team chore.
+	NeverPair [][]string `json:"neverPair,omitempty"`
+	// Together lists groups of chores (by name) that go to the same person, and Apart
+	// groups of chores that never do.
+	Together [][]string `json:"together,omitempty"`
+	Apart    [][]string `json:"apart,omitempty"`
+	// PreferenceTolerance is how far apart, in earnings, the preference strategy may
+	// leave people to give them chores they like.
+	PreferenceTolerance *int `json:"preferenceTolerance,omitempty"`
+	// AuctionBudget is the most an auction may pay out in a week (0 for no limit).
+	AuctionBudget int `json:"auctionBudget,omitempty"`
+	// Surge raises the price of chores nobody wants.
+	Surge *Surge `json:"surge,omitempty"`
+	Hash  string `json:"-"`
+}
+
+// Surge adds a bonus to a chore for each person present who hates it (less one for
+// each who loves it), and for each of the last Weeks saved distributions it was left
+// unassigned in. Each counts Step dollars (1 if unset), up to Max in all.
+type Surge struct {
+	Weeks int `json:"weeks,omitempty"`
+	Step  int `json:"step,omitempty"`
+	Max   int `json:"max"`
+}
+
+// PriceChange records a surge bonus added to a chore, and why.
+type PriceChange struct {
+	Chore  string
+	Base   int
+	Bonus  int
+	Reason string
+}
+
+// CalendarHours is a daily span of time, such as "15:00" to "21:00".
+type CalendarHours struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// Weights sets how much each kind of balance matters when choosing who gets a chore.
+// Only earnings are balanced by default.
+type Weights struct {
+	Earnings   float64 `json:"earnings"`
+	Difficulty float64 `json:"difficulty"`
+	Chores     float64 `json:"chores,omitempty"`
+}
+
+// Rotation discourages giving someone the same chore they had in the last Weeks
+// saved distributions by adding Penalty to their score once for each time they had it.
+type Rotation struct {
+	Weeks   int     `json:"weeks"`
+	Penalty float64 `json:"penalty"`
+}
+
+// CarryOver balances earnings across weeks: each person starts the week with their
+// cumulative surplus or deficit over the last Weeks saved distributions.
+type CarryOver struct {
+	Weeks int `json:"weeks"`
+}
+
+// Exclusion records why a person could not take a chore.
+type Exclusion struct {
+	Person string
+	Reason string
+}
+
+// UnassignedChore is a chore that could not be given to anyone.
+type UnassignedChore struct {
+	Chore    Chore
+	Reason   string
+	Excluded []Exclusion
+}
+
+// Fairness summarizes how evenly a distribution spread earnings and effort.
+type Fairness struct {
+	MinEarned        int
+	MaxEarned        int
+	EarnedSpread     int
+	MinDifficulty    int
+	MaxDifficulty    int
+	DifficultySpread int
+	// EarnedStdDev is the standard deviation of everyone's earnings.
+	EarnedStdDev float64
+	// Gini is the Gini coefficient of everyone's earnings: 0 when they all earned the
+	// same, rising toward 1 the more one person earned of the total.
+	Gini float64
+	// People holds each person's own measures, in the same order as the people.
+	People []PersonFairness
+}
+
+// PersonFairness measures how hard one person worked for what they earned.
+type PersonFairness struct {
+	Name string
+	// DifficultyPerDollar is their total difficulty for each dollar earned, 0 if they
+	// earned nothing.
+	DifficultyPerDollar float64
+	// CapacityUsed is the share of their EffortCapacity taken up, such as 0.8, or 0
+	// if they have no limit.
+	CapacityUsed float64
+}
+
+// DistributionResult is the outcome of a single distribution. People holds a copy of
+// every person with their assigned chores and totals; the input is never modified.
+type DistributionResult struct {
+	People []Person
+	// Away holds the people who were away all week and given no chores.
+	Away       []Person
+	Unassigned []UnassignedChore
+	Fairness   Fairness
+	Seed       uint64
+	Strategy   string
+	Weights    Weights
+	// Budget is what an auction had to spend, if the chores were auctioned.
+	Budget *Budget
+	// Pricing lists the chores given a surge bonus this week.
+	Pricing []PriceChange
+	// Decisions explains how each chore that was handed out was chosen, in order.
+	Decisions []Decision
+}
+
+// Decision explains how a chore, or chores kept together, were handed out.
+type Decision struct {
+	Chores []Chore
+	// People is who got them: one person, or everyone on a team chore.
+	People []string
+	// Rule is how they were chosen from among the people who could take the chores.
+	Rule string
+	// Considered is everyone as they stood just before the decision.
+	Considered []Consideration
+	// Tied lists the people tied under the rule when the choice was drawn at random.
+	// It is empty if there was no random tie-break.
+	Tied []string
+}
+
+// Consideration is one person as they stood when a decision was made.
+type Consideration struct {
+	Person    string
+	Earned    int
+	CarryOver int
+	// CapacityLeft is how much effort capacity they had left, -1 for no limit.
+	CapacityLeft int
+	// Score is their weighted score, plus any rotation penalty for the chores.
+	Score float64
+	// Excluded is why they couldn't take the chores, or "" if they could.
+	Excluded string
+}
+
+// Budget is the weekly amount an auction may pay out, and how much of it was paid.
+// A Limit of 0 means there is no limit.
+type Budget struct {
+	Limit int
+	Spent int
 }
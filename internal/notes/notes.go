@@ -26,12 +26,12 @@ func NewWriter(noteName string, dryRun bool, templatePath string) *Writer {
 	}
 }
 
-func (w *Writer) PrependChoreList(people []models.Person, verbose bool) error {
+func (w *Writer) PrependChoreList(result *models.DistributionResult, verbose bool) error {
 	if runtime.GOOS != "darwin" && !w.DryRun {
 		return fmt.Errorf("Apple Notes is only supported on macOS")
 	}
 
-	content, plainContent, err := w.formatNoteContent(people, verbose)
+	content, plainContent, err := w.formatNoteContent(result, verbose)
 	if err != nil {
 		return err
 	}
@@ -50,13 +50,13 @@ func (w *Writer) PrependChoreList(people []models.Person, verbose bool) error {
 }
 
 // formatNoteContent returns both HTML content (for Notes) and plain text (for dry-run)
-func (w *Writer) formatNoteContent(people []models.Person, verbose bool) (htmlContent string, plainContent string, err error) {
+func (w *Writer) formatNoteContent(result *models.DistributionResult, verbose bool) (htmlContent string, plainContent string, err error) {
 	// If a template path is provided, use it
 	if w.TemplatePath != "" {
 		// Check if template file exists
 		if _, statErr := os.Stat(w.TemplatePath); statErr == nil {
 			// For template-based content, we need to combine all people into one output
-			htmlContent, plainContent, err = w.formatWithTemplate(people, verbose)
+			htmlContent, plainContent, err = w.formatWithTemplate(result, verbose)
 			return
 		}
 		// If template path is specified but file doesn't exist, return error
@@ -65,17 +65,19 @@ func (w *Writer) formatNoteContent(people []models.Person, verbose bool) (htmlCo
 	}
 
 	// Fall back to hardcoded format
-	htmlContent = formatNoteContentHTML(people, verbose)
-	plainContent = formatNoteContentPlain(people, verbose)
+	htmlContent = formatNoteContentHTML(result, verbose)
+	plainContent = formatNoteContentPlain(result, verbose)
 	return
 }
 
 // formatWithTemplate processes all people using the template
-func (w *Writer) formatWithTemplate(people []models.Person, verbose bool) (htmlContent string, plainContent string, err error) {
+func (w *Writer) formatWithTemplate(result *models.DistributionResult, verbose bool) (htmlContent string, plainContent string, err error) {
 	var htmlBuilder, plainBuilder strings.Builder
 
-	for _, person := range people {
-		data := templates.BuildPersonData(person, verbose)
+	for _, person := range result.People {
+		data := templates.BuildPersonData(person, result.Seed, verbose)
+		data.Unassigned = templates.BuildUnassignedData(result.Unassigned)
+		data.Fairness = templates.BuildFairnessData(result.Fairness, person.Name)
 		content, templateErr := templates.LoadAndExecute(w.TemplatePath, data)
 		if templateErr != nil {
 			err = templateErr
@@ -91,54 +93,30 @@ func (w *Writer) formatWithTemplate(people []models.Person, verbose bool) (htmlC
 	return
 }
 
-func formatNoteContentHTML(people []models.Person, verbose bool) string {
+func formatNoteContentHTML(result *models.DistributionResult, verbose bool) string {
 	var sb strings.Builder
 
 	dateStr := time.Now().Format("Monday, January 2, 2006")
 	sb.WriteString(fmt.Sprintf("<div><b>%s</b></div>", dateStr))
 	sb.WriteString("<div><br></div>")
 
-	for _, person := range people {
+	for _, person := range result.People {
 		if verbose && person.EffortCapacity > 0 {
 			sb.WriteString(fmt.Sprintf("<div><b>%s</b> (Capacity: %d)</div>", person.Name, person.EffortCapacity))
 		} else {
 			sb.WriteString(fmt.Sprintf("<div><b>%s</b></div>", person.Name))
 		}
 
-		// Add pre-assigned chores first
-		for _, chore := range person.PreAssignedChores {
-			if verbose {
-				sb.WriteString(fmt.Sprintf("<div>• %s (Difficulty: %d, Earns: $%d)</div>",
-					chore.Name, chore.Difficulty, chore.Earned))
-				if chore.Description != "" {
-					sb.WriteString(fmt.Sprintf("<div style=\"padding-left: 20px; color: #666;\">%s</div>",
-						chore.Description))
-				}
-			} else {
-				sb.WriteString(fmt.Sprintf("<div>• %s — $%d</div>",
-					chore.Name, chore.Earned))
-				if chore.Description != "" {
-					sb.WriteString(fmt.Sprintf("<div style=\"padding-left: 20px; color: #666;\">%s</div>",
-						chore.Description))
-				}
-			}
+		// Add pre-assigned chores first, then distributed chores, grouped by day if
+		// they are scheduled on one
+		anyDay, days := models.ByDay(person.Chores)
+		for _, chore := range append(append([]models.Chore{}, person.PreAssignedChores...), anyDay...) {
+			writeChoreHTML(&sb, person.Name, chore, verbose)
 		}
-		// Then add distributed chores
-		for _, chore := range person.Chores {
-			if verbose {
-				sb.WriteString(fmt.Sprintf("<div>• %s (Difficulty: %d, Earns: $%d)</div>",
-					chore.Name, chore.Difficulty, chore.Earned))
-				if chore.Description != "" {
-					sb.WriteString(fmt.Sprintf("<div style=\"padding-left: 20px; color: #666;\">%s</div>",
-						chore.Description))
-				}
-			} else {
-				sb.WriteString(fmt.Sprintf("<div>• %s — $%d</div>",
-					chore.Name, chore.Earned))
-				if chore.Description != "" {
-					sb.WriteString(fmt.Sprintf("<div style=\"padding-left: 20px; color: #666;\">%s</div>",
-						chore.Description))
-				}
+		for _, day := range days {
+			sb.WriteString(fmt.Sprintf("<div><i>%s</i></div>", day.Date.Format("Monday, Jan 2")))
+			for _, chore := range day.Chores {
+				writeChoreHTML(&sb, person.Name, chore, verbose)
 			}
 		}
 
@@ -151,55 +129,79 @@ func formatNoteContentHTML(people []models.Person, verbose bool) string {
 		sb.WriteString("<div><br></div>")
 	}
 
+	if len(result.Away) > 0 {
+		sb.WriteString("<div><b>Away</b></div>")
+		for _, person := range result.Away {
+			sb.WriteString(fmt.Sprintf("<div>• %s — %s</div>", person.Name, person.Away))
+		}
+		sb.WriteString("<div><br></div>")
+	}
+
+	if len(result.Unassigned) > 0 {
+		sb.WriteString("<div><b>Unassigned</b></div>")
+		for _, u := range result.Unassigned {
+			sb.WriteString(fmt.Sprintf("<div>• %s — %s</div>", u.Chore.Label(), u.Reason))
+		}
+		sb.WriteString("<div><br></div>")
+	}
+
+	if len(result.Pricing) > 0 {
+		sb.WriteString("<div><b>Surge Pricing</b></div>")
+		for _, p := range result.Pricing {
+			sb.WriteString(fmt.Sprintf("<div>• %s +$%d — %s</div>", p.Chore, p.Bonus, p.Reason))
+		}
+		sb.WriteString("<div><br></div>")
+	}
+
+	if result.Seed != 0 {
+		sb.WriteString(fmt.Sprintf("<div>Seed: %d</div>", result.Seed))
+		sb.WriteString("<div><br></div>")
+	}
+
 	sb.WriteString("<div>─────────────────────</div>")
 	sb.WriteString("<div><br></div>")
 
 	return sb.String()
 }
 
-func formatNoteContentPlain(people []models.Person, verbose bool) string {
+// writeChoreHTML writes a chore line for person, with its description below.
+func writeChoreHTML(sb *strings.Builder, person string, chore models.Chore, verbose bool) {
+	if verbose {
+		sb.WriteString(fmt.Sprintf("<div>• %s (Difficulty: %d, Earns: %s)</div>",
+			chore.NameFor(person), chore.Difficulty, chore.Price()))
+	} else {
+		sb.WriteString(fmt.Sprintf("<div>• %s — %s</div>",
+			chore.NameFor(person), chore.Price()))
+	}
+	if chore.Description != "" {
+		sb.WriteString(fmt.Sprintf("<div style=\"padding-left: 20px; color: #666;\">%s</div>",
+			chore.Description))
+	}
+}
+
+func formatNoteContentPlain(result *models.DistributionResult, verbose bool) string {
 	var sb strings.Builder
 
 	dateStr := time.Now().Format("Monday, January 2, 2006")
 	sb.WriteString(fmt.Sprintf("═══ %s ═══\n\n", dateStr))
 
-	for _, person := range people {
+	for _, person := range result.People {
 		if verbose && person.EffortCapacity > 0 {
 			sb.WriteString(fmt.Sprintf("%s (Capacity: %d)\n", person.Name, person.EffortCapacity))
 		} else {
 			sb.WriteString(fmt.Sprintf("%s\n", person.Name))
 		}
 
-		// Add pre-assigned chores first
-		for _, chore := range person.PreAssignedChores {
-			if verbose {
-				sb.WriteString(fmt.Sprintf("  • %s (Difficulty: %d, Earns: $%d)\n",
-					chore.Name, chore.Difficulty, chore.Earned))
-				if chore.Description != "" {
-					sb.WriteString(fmt.Sprintf("    %s\n", chore.Description))
-				}
-			} else {
-				sb.WriteString(fmt.Sprintf("  • %s — $%d\n",
-					chore.Name, chore.Earned))
-				if chore.Description != "" {
-					sb.WriteString(fmt.Sprintf("    %s\n", chore.Description))
-				}
-			}
+		// Add pre-assigned chores first, then distributed chores, grouped by day if
+		// they are scheduled on one
+		anyDay, days := models.ByDay(person.Chores)
+		for _, chore := range append(append([]models.Chore{}, person.PreAssignedChores...), anyDay...) {
+			writeChorePlain(&sb, person.Name, chore, "  ", verbose)
 		}
-		// Then add distributed chores
-		for _, chore := range person.Chores {
-			if verbose {
-				sb.WriteString(fmt.Sprintf("  • %s (Difficulty: %d, Earns: $%d)\n",
-					chore.Name, chore.Difficulty, chore.Earned))
-				if chore.Description != "" {
-					sb.WriteString(fmt.Sprintf("    %s\n", chore.Description))
-				}
-			} else {
-				sb.WriteString(fmt.Sprintf("  • %s — $%d\n",
-					chore.Name, chore.Earned))
-				if chore.Description != "" {
-					sb.WriteString(fmt.Sprintf("    %s\n", chore.Description))
-				}
+		for _, day := range days {
+			sb.WriteString(fmt.Sprintf("  %s\n", day.Date.Format("Monday, Jan 2")))
+			for _, chore := range day.Chores {
+				writeChorePlain(&sb, person.Name, chore, "    ", verbose)
 			}
 		}
 
@@ -211,11 +213,53 @@ func formatNoteContentPlain(people []models.Person, verbose bool) string {
 		}
 	}
 
+	if len(result.Away) > 0 {
+		sb.WriteString("Away\n")
+		for _, person := range result.Away {
+			sb.WriteString(fmt.Sprintf("  • %s — %s\n", person.Name, person.Away))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(result.Unassigned) > 0 {
+		sb.WriteString("Unassigned\n")
+		for _, u := range result.Unassigned {
+			sb.WriteString(fmt.Sprintf("  • %s — %s\n", u.Chore.Label(), u.Reason))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(result.Pricing) > 0 {
+		sb.WriteString("Surge Pricing\n")
+		for _, p := range result.Pricing {
+			sb.WriteString(fmt.Sprintf("  • %s +$%d — %s\n", p.Chore, p.Bonus, p.Reason))
+		}
+		sb.WriteString("\n")
+	}
+
+	if result.Seed != 0 {
+		sb.WriteString(fmt.Sprintf("Seed: %d\n\n", result.Seed))
+	}
+
 	sb.WriteString("────────────────────────\n")
 
 	return sb.String()
 }
 
+// writeChorePlain writes a chore line for person at the given indent, with its description below.
+func writeChorePlain(sb *strings.Builder, person string, chore models.Chore, indent string, verbose bool) {
+	if verbose {
+		sb.WriteString(fmt.Sprintf("%s• %s (Difficulty: %d, Earns: %s)\n",
+			indent, chore.NameFor(person), chore.Difficulty, chore.Price()))
+	} else {
+		sb.WriteString(fmt.Sprintf("%s• %s — %s\n",
+			indent, chore.NameFor(person), chore.Price()))
+	}
+	if chore.Description != "" {
+		sb.WriteString(fmt.Sprintf("%s  %s\n", indent, chore.Description))
+	}
+}
+
 func updateNote(noteName, newContent string) error {
 	escapedContent := strings.ReplaceAll(newContent, `\`, `\\`)
 	escapedContent = strings.ReplaceAll(escapedContent, `"`, `\"`)
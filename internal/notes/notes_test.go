@@ -40,7 +40,7 @@ func TestFormatNoteContentHTML_Default(t *testing.T) {
 		},
 	}
 
-	content := formatNoteContentHTML(people, false)
+	content := formatNoteContentHTML(&models.DistributionResult{People: people}, false)
 
 	today := time.Now().Format("January 2, 2006")
 	if !strings.Contains(content, today) {
@@ -88,7 +88,7 @@ func TestFormatNoteContentHTML_Verbose(t *testing.T) {
 		},
 	}
 
-	content := formatNoteContentHTML(people, true)
+	content := formatNoteContentHTML(&models.DistributionResult{People: people}, true)
 
 	if !strings.Contains(content, "(Capacity: 15)") {
 		t.Error("Verbose content should contain capacity")
@@ -116,7 +116,7 @@ func TestFormatNoteContentHTML_VerboseNoCapacity(t *testing.T) {
 		},
 	}
 
-	content := formatNoteContentHTML(people, true)
+	content := formatNoteContentHTML(&models.DistributionResult{People: people}, true)
 
 	if !strings.Contains(content, "Difficulty: 6") {
 		t.Error("Verbose content should contain difficulty")
@@ -149,7 +149,7 @@ func TestFormatNoteContentHTML_MultiplePeople(t *testing.T) {
 		},
 	}
 
-	content := formatNoteContentHTML(people, false)
+	content := formatNoteContentHTML(&models.DistributionResult{People: people}, false)
 
 	if !strings.Contains(content, "<b>Alice</b>") {
 		t.Error("Content should contain Alice")
@@ -186,7 +186,7 @@ func TestFormatNoteContentHTML_MultipleChores(t *testing.T) {
 		},
 	}
 
-	content := formatNoteContentHTML(people, false)
+	content := formatNoteContentHTML(&models.DistributionResult{People: people}, false)
 
 	if !strings.Contains(content, "• Kitchen") {
 		t.Error("Content should contain Kitchen")
@@ -210,7 +210,7 @@ func TestFormatNoteContentPlain_Default(t *testing.T) {
 		},
 	}
 
-	content := formatNoteContentPlain(people, false)
+	content := formatNoteContentPlain(&models.DistributionResult{People: people}, false)
 
 	today := time.Now().Format("January 2, 2006")
 	if !strings.Contains(content, today) {
@@ -250,7 +250,7 @@ func TestFormatNoteContentPlain_Verbose(t *testing.T) {
 		},
 	}
 
-	content := formatNoteContentPlain(people, true)
+	content := formatNoteContentPlain(&models.DistributionResult{People: people}, true)
 
 	if !strings.Contains(content, "(Capacity: 15)") {
 		t.Error("Verbose plain content should contain capacity")
@@ -278,7 +278,7 @@ func TestFormatNoteContentPlain_VerboseNoCapacity(t *testing.T) {
 		},
 	}
 
-	content := formatNoteContentPlain(people, true)
+	content := formatNoteContentPlain(&models.DistributionResult{People: people}, true)
 
 	if !strings.Contains(content, "Difficulty: 6") {
 		t.Error("Verbose plain content should contain difficulty")
@@ -301,7 +301,7 @@ func TestFormatNoteContentHTML_EmptyChores(t *testing.T) {
 		},
 	}
 
-	content := formatNoteContentHTML(people, false)
+	content := formatNoteContentHTML(&models.DistributionResult{People: people}, false)
 
 	if !strings.Contains(content, "<b>Alice</b>") {
 		t.Error("Content should contain person name even with no chores")
@@ -328,7 +328,7 @@ func TestFormatNoteContentHTML_WithDescription(t *testing.T) {
 		},
 	}
 
-	content := formatNoteContentHTML(people, false)
+	content := formatNoteContentHTML(&models.DistributionResult{People: people}, false)
 
 	if !strings.Contains(content, "• Kitchen") {
 		t.Error("Content should contain chore name")
@@ -360,7 +360,7 @@ func TestFormatNoteContentHTML_VerboseWithDescription(t *testing.T) {
 		},
 	}
 
-	content := formatNoteContentHTML(people, true)
+	content := formatNoteContentHTML(&models.DistributionResult{People: people}, true)
 
 	if !strings.Contains(content, "Living Room") {
 		t.Error("Content should contain chore name")
@@ -385,7 +385,7 @@ func TestFormatNoteContentPlain_WithDescription(t *testing.T) {
 		},
 	}
 
-	content := formatNoteContentPlain(people, false)
+	content := formatNoteContentPlain(&models.DistributionResult{People: people}, false)
 
 	if !strings.Contains(content, "• Kitchen") {
 		t.Error("Content should contain chore name")
@@ -411,7 +411,7 @@ func TestFormatNoteContentPlain_VerboseWithDescription(t *testing.T) {
 		},
 	}
 
-	content := formatNoteContentPlain(people, true)
+	content := formatNoteContentPlain(&models.DistributionResult{People: people}, true)
 
 	if !strings.Contains(content, "Living Room") {
 		t.Error("Content should contain chore name")
@@ -438,7 +438,7 @@ func TestFormatNoteContentHTML_WithPreAssignedChores(t *testing.T) {
 		},
 	}
 
-	content := formatNoteContentHTML(people, false)
+	content := formatNoteContentHTML(&models.DistributionResult{People: people}, false)
 
 	if !strings.Contains(content, "Clean Bedroom") {
 		t.Error("Content should contain pre-assigned chore")
@@ -475,7 +475,7 @@ func TestFormatNoteContentPlain_WithPreAssignedChores(t *testing.T) {
 		},
 	}
 
-	content := formatNoteContentPlain(people, false)
+	content := formatNoteContentPlain(&models.DistributionResult{People: people}, false)
 
 	if !strings.Contains(content, "Clean Bedroom") {
 		t.Error("Content should contain pre-assigned chore")
@@ -494,3 +494,97 @@ func TestFormatNoteContentPlain_WithPreAssignedChores(t *testing.T) {
 		t.Error("Pre-assigned chore should appear before distributed chore")
 	}
 }
+
+func TestFormatNoteContent_WithSeed(t *testing.T) {
+	people := []models.Person{
+		{
+			Name:        "Alice",
+			TotalEarned: 5,
+			Chores: []models.Chore{
+				{Name: "Kitchen", Difficulty: 6, Earned: 5},
+			},
+		},
+	}
+
+	html := formatNoteContentHTML(&models.DistributionResult{People: people, Seed: 12345}, false)
+	if !strings.Contains(html, "<div>Seed: 12345</div>") {
+		t.Error("HTML content should contain the seed")
+	}
+
+	plain := formatNoteContentPlain(&models.DistributionResult{People: people, Seed: 12345}, false)
+	if !strings.Contains(plain, "Seed: 12345") {
+		t.Error("Plain content should contain the seed")
+	}
+
+	if strings.Contains(formatNoteContentPlain(&models.DistributionResult{People: people}, false), "Seed:") {
+		t.Error("Plain content should not contain a seed when it is unknown")
+	}
+}
+
+func TestFormatNoteContent_WithUnassigned(t *testing.T) {
+	result := &models.DistributionResult{
+		People: []models.Person{
+			{Name: "Alice", EffortCapacity: 5},
+		},
+		Unassigned: []models.UnassignedChore{
+			{Chore: models.Chore{Name: "Garage", Earned: 6}, Reason: "no one has capacity"},
+		},
+	}
+
+	html := formatNoteContentHTML(result, false)
+	if !strings.Contains(html, "<div><b>Unassigned</b></div>") {
+		t.Error("HTML content should contain an unassigned section")
+	}
+	if !strings.Contains(html, "• Garage — no one has capacity") {
+		t.Error("HTML content should list the unassigned chore with its reason")
+	}
+
+	plain := formatNoteContentPlain(result, false)
+	if !strings.Contains(plain, "• Garage — no one has capacity") {
+		t.Error("Plain content should list the unassigned chore with its reason")
+	}
+}
+
+func TestFormatNoteContent_WithSurge(t *testing.T) {
+	result := &models.DistributionResult{
+		People: []models.Person{
+			{Name: "Alice", Chores: []models.Chore{{Name: "Bathroom", Earned: 6, Surge: 2}}, TotalEarned: 6},
+		},
+		Pricing: []models.PriceChange{{Chore: "Bathroom", Base: 4, Bonus: 2, Reason: "hated by Alice and Bob"}},
+	}
+
+	html := formatNoteContentHTML(result, false)
+	if !strings.Contains(html, "• Bathroom — $6 ($4 + $2 surge)") {
+		t.Error("HTML content should show the surge bonus")
+	}
+	if !strings.Contains(html, "<div>• Bathroom +$2 — hated by Alice and Bob</div>") {
+		t.Error("HTML content should log the pricing decision")
+	}
+
+	plain := formatNoteContentPlain(result, false)
+	if !strings.Contains(plain, "Surge Pricing\n  • Bathroom +$2 — hated by Alice and Bob") {
+		t.Error("Plain content should log the pricing decision")
+	}
+}
+
+func TestFormatNoteContent_WithAway(t *testing.T) {
+	result := &models.DistributionResult{
+		People: []models.Person{
+			{Name: "Alice", EffortCapacity: 5},
+		},
+		Away: []models.Person{{Name: "Bob", Away: "camp"}},
+	}
+
+	html := formatNoteContentHTML(result, false)
+	if !strings.Contains(html, "<div><b>Away</b></div><div>• Bob — camp</div>") {
+		t.Error("HTML content should list who is away and why")
+	}
+	if strings.Contains(html, "<div><b>Bob</b></div>") {
+		t.Error("HTML content should not list chores for someone who is away")
+	}
+
+	plain := formatNoteContentPlain(result, false)
+	if !strings.Contains(plain, "Away\n  • Bob — camp") {
+		t.Error("Plain content should list who is away and why")
+	}
+}
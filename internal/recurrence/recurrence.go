@@ -0,0 +1,191 @@
+// Package recurrence expands recurring chores into the dated instances due in a week.
+package recurrence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// Kind is how often a chore recurs.
+type Kind int
+
+const (
+	// Weekly chores happen once per distribution. This is the default.
+	Weekly Kind = iota
+	// Daily chores happen on each of the 7 days of the week.
+	Daily
+	// Weekdays chores happen Monday through Friday.
+	Weekdays
+	// TimesPerWeek chores happen Times times, spread evenly across the week.
+	TimesPerWeek
+	// Biweekly chores happen every other week.
+	Biweekly
+	// Monthly chores happen once per calendar month.
+	Monthly
+)
+
+// Frequency is a parsed Chore.Frequency.
+type Frequency struct {
+	Kind  Kind
+	Times int // for TimesPerWeek
+}
+
+// Parse reads a frequency: "daily", "weekdays", "weekly", "biweekly" (or "every other
+// week"), "monthly", or "N/week" for N times a week. An empty string is weekly.
+func Parse(s string) (Frequency, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "", "weekly":
+		return Frequency{Kind: Weekly}, nil
+	case "daily":
+		return Frequency{Kind: Daily}, nil
+	case "weekdays":
+		return Frequency{Kind: Weekdays}, nil
+	case "biweekly", "every other week":
+		return Frequency{Kind: Biweekly}, nil
+	case "monthly":
+		return Frequency{Kind: Monthly}, nil
+	}
+
+	if times, ok := strings.CutSuffix(s, "/week"); ok {
+		n, err := strconv.Atoi(strings.TrimSpace(times))
+		if err != nil || n < 1 || n > 7 {
+			return Frequency{}, fmt.Errorf("invalid frequency %q: times per week must be 1 to 7", s)
+		}
+		return Frequency{Kind: TimesPerWeek, Times: n}, nil
+	}
+
+	return Frequency{}, fmt.Errorf("invalid frequency %q (want daily, weekdays, weekly, biweekly, monthly or N/week)", s)
+}
+
+// Expand returns the chores due in the week starting on weekStart. Daily, weekday and
+// N/week chores become one instance per day they are due, each with its Date set; if
+// the chore has Days, only those days are used.
+// Biweekly and monthly chores are skipped if they are not due yet, judging by last:
+// when each chore (by name) was last distributed. Other chores are returned as is.
+func Expand(chores []models.Chore, weekStart time.Time, last map[string]time.Time) ([]models.Chore, error) {
+	start := midnight(weekStart)
+
+	var expanded []models.Chore
+	for _, chore := range chores {
+		if err := Validate(chore); err != nil {
+			return nil, err
+		}
+		freq, _ := Parse(chore.Frequency)
+
+		days := freq.days(start, chore)
+		if days == nil {
+			if freq.Due(start, last[chore.Name]) {
+				expanded = append(expanded, chore)
+			}
+			continue
+		}
+		for _, offset := range days {
+			instance := chore
+			instance.Date = start.AddDate(0, 0, offset)
+			expanded = append(expanded, instance)
+		}
+	}
+	return expanded, nil
+}
+
+// days returns the day offsets from start of each instance, or nil for chores that
+// happen once when due.
+func (f Frequency) days(start time.Time, chore models.Chore) []int {
+	if f.Kind != Daily && f.Kind != Weekdays && f.Kind != TimesPerWeek {
+		return nil
+	}
+
+	var allowed []int
+	for d := 0; d < 7; d++ {
+		wd := start.AddDate(0, 0, d).Weekday()
+		if f.Kind == Weekdays && (wd == time.Saturday || wd == time.Sunday) {
+			continue
+		}
+		if AllowedOn(chore, wd) {
+			allowed = append(allowed, d)
+		}
+	}
+	if f.Kind != TimesPerWeek || f.Times >= len(allowed) {
+		return allowed
+	}
+
+	days := make([]int, f.Times)
+	for i := range days {
+		days[i] = allowed[i*len(allowed)/f.Times]
+	}
+	return days
+}
+
+// Validate checks a chore's Frequency and Days.
+func Validate(chore models.Chore) error {
+	freq, err := Parse(chore.Frequency)
+	if err != nil {
+		return fmt.Errorf("chore %q: %w", chore.Name, err)
+	}
+	for _, day := range chore.Days {
+		if _, err := ParseWeekday(day); err != nil {
+			return fmt.Errorf("chore %q: %w", chore.Name, err)
+		}
+	}
+	if freq.Kind == TimesPerWeek && len(chore.Days) > 0 && freq.Times > len(chore.Days) {
+		return fmt.Errorf("chore %q: %d/week needs at least %d allowed days, has %d",
+			chore.Name, freq.Times, freq.Times, len(chore.Days))
+	}
+	return nil
+}
+
+// ParseWeekday reads a day of the week such as "Tue" or "tuesday".
+func ParseWeekday(s string) (time.Weekday, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if len(name) >= 3 {
+		for wd := time.Sunday; wd <= time.Saturday; wd++ {
+			if full := strings.ToLower(wd.String()); strings.HasPrefix(full, name) {
+				return wd, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid day of the week %q", s)
+}
+
+// AllowedOn reports whether the chore may be done on the given day of the week: any
+// day if it has no Days, otherwise one of them.
+func AllowedOn(chore models.Chore, day time.Weekday) bool {
+	if len(chore.Days) == 0 {
+		return true
+	}
+	for _, name := range chore.Days {
+		if wd, err := ParseWeekday(name); err == nil && wd == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Due reports whether a chore that happens once when due should be done in the week
+// starting on start, given when it was last distributed (zero if never). Biweekly
+// chores skip the week after they were done. Monthly chores are done once per calendar
+// month, and never two weeks in a row.
+func (f Frequency) Due(start, last time.Time) bool {
+	if last.IsZero() {
+		return true
+	}
+	start, last = midnight(start), midnight(last)
+	doneLastWeek := !last.Before(start.AddDate(0, 0, -7))
+	switch f.Kind {
+	case Biweekly:
+		return !doneLastWeek
+	case Monthly:
+		sameMonth := last.Year() == start.Year() && last.Month() == start.Month()
+		return !sameMonth && !doneLastWeek
+	}
+	return true
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,162 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/faradayfan/chore-distributor/internal/models"
+)
+
+// monday is the start of the week used throughout these tests.
+var monday = time.Date(2025, time.June, 2, 9, 30, 0, 0, time.UTC)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Frequency
+		wantErr bool
+	}{
+		{in: "", want: Frequency{Kind: Weekly}},
+		{in: "weekly", want: Frequency{Kind: Weekly}},
+		{in: "Daily", want: Frequency{Kind: Daily}},
+		{in: "weekdays", want: Frequency{Kind: Weekdays}},
+		{in: "every other week", want: Frequency{Kind: Biweekly}},
+		{in: "biweekly", want: Frequency{Kind: Biweekly}},
+		{in: "monthly", want: Frequency{Kind: Monthly}},
+		{in: "3/week", want: Frequency{Kind: TimesPerWeek, Times: 3}},
+		{in: "0/week", wantErr: true},
+		{in: "8/week", wantErr: true},
+		{in: "x/week", wantErr: true},
+		{in: "hourly", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Parse(%q): expected an error", tt.in)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestExpand_Instances(t *testing.T) {
+	chores := []models.Chore{
+		{Name: "Dishes", Difficulty: 2, Earned: 1, Frequency: "daily"},
+		{Name: "Lunches", Difficulty: 1, Earned: 1, Frequency: "weekdays"},
+		{Name: "Vacuum", Difficulty: 3, Earned: 2, Frequency: "3/week"},
+		{Name: "Garage", Difficulty: 8, Earned: 6},
+	}
+
+	expanded, err := Expand(chores, monday, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	days := make(map[string][]time.Weekday)
+	for _, chore := range expanded {
+		if !chore.Date.IsZero() {
+			if chore.Date.Hour() != 0 {
+				t.Errorf("Expected %s to be dated at midnight, got %v", chore.Name, chore.Date)
+			}
+			days[chore.Name] = append(days[chore.Name], chore.Date.Weekday())
+		} else {
+			days[chore.Name] = append(days[chore.Name], -1)
+		}
+	}
+
+	if len(days["Dishes"]) != 7 {
+		t.Errorf("Expected 7 daily instances, got %v", days["Dishes"])
+	}
+	if got := days["Lunches"]; len(got) != 5 || got[0] != time.Monday || got[4] != time.Friday {
+		t.Errorf("Expected Monday to Friday, got %v", got)
+	}
+	if got := days["Vacuum"]; len(got) != 3 || got[0] != time.Monday || got[1] != time.Wednesday || got[2] != time.Friday {
+		t.Errorf("Expected Monday, Wednesday and Friday, got %v", got)
+	}
+	if got := days["Garage"]; len(got) != 1 || got[0] != -1 {
+		t.Errorf("Expected one undated Garage chore, got %v", got)
+	}
+}
+
+func TestExpand_WeekdaysFromMidweek(t *testing.T) {
+	thursday := monday.AddDate(0, 0, 3)
+	expanded, err := Expand([]models.Chore{{Name: "Lunches", Frequency: "weekdays"}}, thursday, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expanded) != 5 {
+		t.Fatalf("Expected 5 weekday instances in any 7-day window, got %d", len(expanded))
+	}
+	for _, chore := range expanded {
+		if wd := chore.Date.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			t.Errorf("Unexpected weekend instance on %v", chore.Date)
+		}
+	}
+}
+
+func TestExpand_SkipsChoresNotDue(t *testing.T) {
+	chores := []models.Chore{
+		{Name: "Windows", Frequency: "biweekly"},
+		{Name: "Fridge", Frequency: "monthly"},
+	}
+
+	tests := []struct {
+		name string
+		last map[string]time.Time
+		want []string
+	}{
+		{name: "never done", want: []string{"Windows", "Fridge"}},
+		{
+			name: "done last week",
+			last: map[string]time.Time{"Windows": monday.AddDate(0, 0, -7), "Fridge": monday.AddDate(0, 0, -7)},
+			want: nil,
+		},
+		{
+			name: "done two weeks ago in the previous month",
+			last: map[string]time.Time{"Windows": monday.AddDate(0, 0, -14), "Fridge": monday.AddDate(0, 0, -14)},
+			want: []string{"Windows", "Fridge"},
+		},
+		{
+			name: "run a day early two weeks later",
+			last: map[string]time.Time{"Windows": monday.AddDate(0, 0, -13), "Fridge": monday.AddDate(0, 0, -6)},
+			want: []string{"Windows"},
+		},
+		{
+			name: "done earlier this month",
+			last: map[string]time.Time{"Fridge": time.Date(2025, time.June, 1, 8, 0, 0, 0, time.UTC)},
+			want: []string{"Windows"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded, err := Expand(chores, monday, tt.last)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, chore := range expanded {
+				got = append(got, chore.Name)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestExpand_InvalidFrequency(t *testing.T) {
+	if _, err := Expand([]models.Chore{{Name: "Dishes", Frequency: "sometimes"}}, monday, nil); err == nil {
+		t.Error("Expected an error for an invalid frequency")
+	}
+}
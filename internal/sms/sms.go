@@ -23,19 +23,23 @@ func NewSender(dryRun bool, templatePath string) *Sender {
 	}
 }
 
-func (s *Sender) SendChoreAssignments(people []models.Person, verbose bool) error {
+func (s *Sender) SendChoreAssignments(result *models.DistributionResult, verbose bool) error {
 	if runtime.GOOS != "darwin" && !s.DryRun {
 		return fmt.Errorf("iMessage is only supported on macOS")
 	}
 
+	for _, person := range result.Away {
+		fmt.Printf("Skipping %s: away (%s)\n", person.Name, person.Away)
+	}
+
 	var errs []string
-	for _, person := range people {
+	for _, person := range result.People {
 		if person.Contact == "" {
 			fmt.Printf("Skipping %s: no contact configured\n", person.Name)
 			continue
 		}
 
-		message, err := s.formatMessage(person, verbose)
+		message, err := s.formatMessage(person, result.Fairness, result.Seed, verbose)
 		if err != nil {
 			errs = append(errs, fmt.Sprintf("%s: %v", person.Name, err))
 			continue
@@ -61,12 +65,56 @@ func (s *Sender) SendChoreAssignments(people []models.Person, verbose bool) erro
 	return nil
 }
 
-func (s *Sender) formatMessage(person models.Person, verbose bool) (string, error) {
+// SendUnassignedAlert tells a parent which chores could not be assigned and why.
+// It does nothing when every chore was assigned.
+func (s *Sender) SendUnassignedAlert(contact string, result *models.DistributionResult) error {
+	if len(result.Unassigned) == 0 {
+		return nil
+	}
+	if runtime.GOOS != "darwin" && !s.DryRun {
+		return fmt.Errorf("iMessage is only supported on macOS")
+	}
+
+	message := formatUnassignedAlert(result)
+
+	if s.DryRun {
+		fmt.Printf("\n--- Would send to parent (%s) ---\n%s\n", contact, message)
+		return nil
+	}
+
+	if err := sendViaMessages(contact, message); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Sent unassigned chore alert to %s\n", contact)
+	return nil
+}
+
+func formatUnassignedAlert(result *models.DistributionResult) string {
+	var sb strings.Builder
+
+	sb.WriteString("Some chores could not be assigned this week:\n\n")
+	for _, u := range result.Unassigned {
+		sb.WriteString(fmt.Sprintf("• %s (Earns: $%d): %s\n", u.Chore.Label(), u.Chore.Earned, u.Reason))
+		for _, e := range u.Excluded {
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", e.Person, e.Reason))
+		}
+	}
+
+	if result.Seed != 0 {
+		sb.WriteString(fmt.Sprintf("\nSeed: %d", result.Seed))
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func (s *Sender) formatMessage(person models.Person, fairness models.Fairness, seed uint64, verbose bool) (string, error) {
 	// If a template path is provided, use it
 	if s.TemplatePath != "" {
 		// Check if template file exists
 		if _, err := os.Stat(s.TemplatePath); err == nil {
-			data := templates.BuildPersonData(person, verbose)
+			data := templates.BuildPersonData(person, seed, verbose)
+			data.Fairness = templates.BuildFairnessData(fairness, person.Name)
 			return templates.LoadAndExecute(s.TemplatePath, data)
 		}
 		// If template path is specified but file doesn't exist, return error
@@ -78,36 +126,20 @@ func (s *Sender) formatMessage(person models.Person, verbose bool) (string, erro
 
 	sb.WriteString(fmt.Sprintf("Hi %s! Here are your chores:\n\n", person.Name))
 
-	// Add pre-assigned chores first
-	for _, chore := range person.PreAssignedChores {
-		if verbose {
-			sb.WriteString(fmt.Sprintf("• %s (Difficulty: %d, Earns: $%d)\n",
-				chore.Name, chore.Difficulty, chore.Earned))
-			if chore.Description != "" {
-				sb.WriteString(fmt.Sprintf("  %s\n", chore.Description))
-			}
-		} else {
-			sb.WriteString(fmt.Sprintf("• %s (Earns: $%d)\n",
-				chore.Name, chore.Earned))
-			if chore.Description != "" {
-				sb.WriteString(fmt.Sprintf("  %s\n", chore.Description))
-			}
-		}
+	// Add pre-assigned chores first, then distributed chores, grouped by day if they
+	// are scheduled on one
+	anyDay, days := models.ByDay(person.Chores)
+	anyDay = append(append([]models.Chore{}, person.PreAssignedChores...), anyDay...)
+	for _, chore := range anyDay {
+		writeChore(&sb, person.Name, chore, verbose)
 	}
-	// Then add distributed chores
-	for _, chore := range person.Chores {
-		if verbose {
-			sb.WriteString(fmt.Sprintf("• %s (Difficulty: %d, Earns: $%d)\n",
-				chore.Name, chore.Difficulty, chore.Earned))
-			if chore.Description != "" {
-				sb.WriteString(fmt.Sprintf("  %s\n", chore.Description))
-			}
-		} else {
-			sb.WriteString(fmt.Sprintf("• %s (Earns: $%d)\n",
-				chore.Name, chore.Earned))
-			if chore.Description != "" {
-				sb.WriteString(fmt.Sprintf("  %s\n", chore.Description))
-			}
+	for i, day := range days {
+		if i > 0 || len(anyDay) > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("%s:\n", day.Date.Format("Monday, Jan 2")))
+		for _, chore := range day.Chores {
+			writeChore(&sb, person.Name, chore, verbose)
 		}
 	}
 
@@ -117,9 +149,27 @@ func (s *Sender) formatMessage(person models.Person, verbose bool) (string, erro
 		sb.WriteString(fmt.Sprintf("\nEffort: %d / %d", person.TotalDifficulty, person.EffortCapacity))
 	}
 
+	if seed != 0 {
+		sb.WriteString(fmt.Sprintf("\nSeed: %d", seed))
+	}
+
 	return sb.String(), nil
 }
 
+// writeChore writes a chore line for person, with its description below.
+func writeChore(sb *strings.Builder, person string, chore models.Chore, verbose bool) {
+	if verbose {
+		sb.WriteString(fmt.Sprintf("• %s (Difficulty: %d, Earns: %s)\n",
+			chore.NameFor(person), chore.Difficulty, chore.Price()))
+	} else {
+		sb.WriteString(fmt.Sprintf("• %s (Earns: %s)\n",
+			chore.NameFor(person), chore.Price()))
+	}
+	if chore.Description != "" {
+		sb.WriteString(fmt.Sprintf("  %s\n", chore.Description))
+	}
+}
+
 func sendViaMessages(contact, message string) error {
 	escapedMessage := strings.ReplaceAll(message, `\`, `\\`)
 	escapedMessage = strings.ReplaceAll(escapedMessage, `"`, `\"`)
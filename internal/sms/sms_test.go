@@ -1,6 +1,8 @@
 package sms
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -20,7 +22,7 @@ func TestFormatMessage_Default(t *testing.T) {
 	}
 
 	sender := NewSender(false, "")
-	message, err := sender.formatMessage(person, false)
+	message, err := sender.formatMessage(person, models.Fairness{}, 0, false)
 	if err != nil {
 		t.Fatalf("formatMessage returned error: %v", err)
 	}
@@ -60,7 +62,7 @@ func TestFormatMessage_Verbose(t *testing.T) {
 	}
 
 	sender := NewSender(false, "")
-	message, err := sender.formatMessage(person, true)
+	message, err := sender.formatMessage(person, models.Fairness{}, 0, true)
 	if err != nil {
 		t.Fatalf("formatMessage returned error: %v", err)
 	}
@@ -86,7 +88,7 @@ func TestFormatMessage_VerboseNoCapacity(t *testing.T) {
 	}
 
 	sender := NewSender(false, "")
-	message, err := sender.formatMessage(person, true)
+	message, err := sender.formatMessage(person, models.Fairness{}, 0, true)
 	if err != nil {
 		t.Fatalf("formatMessage returned error: %v", err)
 	}
@@ -112,7 +114,7 @@ func TestFormatMessage_MultipleChores(t *testing.T) {
 	}
 
 	sender := NewSender(false, "")
-	message, err := sender.formatMessage(person, false)
+	message, err := sender.formatMessage(person, models.Fairness{}, 0, false)
 	if err != nil {
 		t.Fatalf("formatMessage returned error: %v", err)
 	}
@@ -154,7 +156,7 @@ func TestFormatMessage_EmailContact(t *testing.T) {
 	}
 
 	sender := NewSender(false, "")
-	message, err := sender.formatMessage(person, false)
+	message, err := sender.formatMessage(person, models.Fairness{}, 0, false)
 	if err != nil {
 		t.Fatalf("formatMessage returned error: %v", err)
 	}
@@ -179,7 +181,7 @@ func TestFormatMessage_WithDescription(t *testing.T) {
 	}
 
 	sender := NewSender(false, "")
-	message, err := sender.formatMessage(person, false)
+	message, err := sender.formatMessage(person, models.Fairness{}, 0, false)
 	if err != nil {
 		t.Fatalf("formatMessage returned error: %v", err)
 	}
@@ -208,7 +210,7 @@ func TestFormatMessage_VerboseWithDescription(t *testing.T) {
 	}
 
 	sender := NewSender(false, "")
-	message, err := sender.formatMessage(person, true)
+	message, err := sender.formatMessage(person, models.Fairness{}, 0, true)
 	if err != nil {
 		t.Fatalf("formatMessage returned error: %v", err)
 	}
@@ -238,7 +240,7 @@ func TestFormatMessage_WithPreAssignedChores(t *testing.T) {
 	}
 
 	sender := NewSender(false, "")
-	message, err := sender.formatMessage(person, false)
+	message, err := sender.formatMessage(person, models.Fairness{}, 0, false)
 	if err != nil {
 		t.Fatalf("formatMessage returned error: %v", err)
 	}
@@ -263,3 +265,109 @@ func TestFormatMessage_WithPreAssignedChores(t *testing.T) {
 		t.Error("Pre-assigned chore should appear before distributed chore")
 	}
 }
+
+func TestFormatMessage_WithSeed(t *testing.T) {
+	person := models.Person{
+		Name:        "Alice",
+		Contact:     "+1234567890",
+		TotalEarned: 5,
+		Chores: []models.Chore{
+			{Name: "Kitchen", Difficulty: 6, Earned: 5},
+		},
+	}
+
+	sender := NewSender(false, "")
+	message, err := sender.formatMessage(person, models.Fairness{}, 12345, false)
+	if err != nil {
+		t.Fatalf("formatMessage returned error: %v", err)
+	}
+	if !strings.Contains(message, "Seed: 12345") {
+		t.Error("Message should contain the seed")
+	}
+
+	message, err = sender.formatMessage(person, models.Fairness{}, 0, false)
+	if err != nil {
+		t.Fatalf("formatMessage returned error: %v", err)
+	}
+	if strings.Contains(message, "Seed:") {
+		t.Error("Message should not contain a seed when it is unknown")
+	}
+}
+
+func TestFormatUnassignedAlert(t *testing.T) {
+	result := &models.DistributionResult{
+		Unassigned: []models.UnassignedChore{
+			{
+				Chore:  models.Chore{Name: "Garage", Difficulty: 8, Earned: 6},
+				Reason: "no one has capacity",
+				Excluded: []models.Exclusion{
+					{Person: "Alice", Reason: "only 2 of 5 effort capacity left, chore needs 8"},
+				},
+			},
+		},
+		Seed: 7,
+	}
+
+	message := formatUnassignedAlert(result)
+
+	if !strings.Contains(message, "Garage (Earns: $6): no one has capacity") {
+		t.Error("Alert should contain the unassigned chore and reason")
+	}
+	if !strings.Contains(message, "Alice: only 2 of 5 effort capacity left") {
+		t.Error("Alert should explain which capacity limits blocked the chore")
+	}
+	if !strings.Contains(message, "Seed: 7") {
+		t.Error("Alert should contain the seed")
+	}
+}
+
+func TestSendUnassignedAlert_NothingUnassigned(t *testing.T) {
+	sender := NewSender(false, "")
+	if err := sender.SendUnassignedAlert("+1234567890", &models.DistributionResult{}); err != nil {
+		t.Errorf("Expected no error when nothing is unassigned, got %v", err)
+	}
+}
+
+func TestFormatMessage_Surge(t *testing.T) {
+	person := models.Person{
+		Name:        "Alice",
+		TotalEarned: 6,
+		Chores: []models.Chore{
+			{Name: "Bathroom", Difficulty: 5, Earned: 6, Surge: 2},
+		},
+	}
+
+	sender := NewSender(false, "")
+	message, err := sender.formatMessage(person, models.Fairness{}, 0, false)
+	if err != nil {
+		t.Fatalf("formatMessage returned error: %v", err)
+	}
+	if !strings.Contains(message, "Bathroom (Earns: $6 ($4 + $2 surge))") {
+		t.Errorf("Message should show the surge bonus, got:\n%s", message)
+	}
+}
+
+func TestFormatMessage_TemplateFairness(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sms.txt")
+	template := `{{.PersonName}}: Gini {{printf "%.2f" .Fairness.Gini}}, {{printf "%.0f" .Fairness.CapacityUsed}} used, {{printf "%.1f" .Fairness.DifficultyPerDollar}} per dollar`
+	if err := os.WriteFile(path, []byte(template), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	person := models.Person{Name: "Alice", TotalEarned: 4, TotalDifficulty: 6, EffortCapacity: 6}
+	fairness := models.Fairness{
+		Gini: 0.25,
+		People: []models.PersonFairness{
+			{Name: "Bob", DifficultyPerDollar: 9},
+			{Name: "Alice", DifficultyPerDollar: 1.5, CapacityUsed: 1},
+		},
+	}
+
+	message, err := NewSender(false, path).formatMessage(person, fairness, 0, false)
+	if err != nil {
+		t.Fatalf("formatMessage returned error: %v", err)
+	}
+	if message != "Alice: Gini 0.25, 1 used, 1.5 per dollar" {
+		t.Errorf("Unexpected message: %q", message)
+	}
+}
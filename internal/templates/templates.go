@@ -13,9 +13,39 @@ import (
 // ChoreData represents a single chore for template rendering
 type ChoreData struct {
 	Name        string
+	Day         string // e.g. "Tue Jun 3" if the chore is scheduled on a day, otherwise empty
 	Difficulty  int
 	Earned      float64
+	Surge       float64 // the part of Earned that is a surge bonus, 0 if none
 	Description string
+	With        string // for a team chore, who else does it, e.g. "John, Mary"
+}
+
+// DayData represents the chores a person has scheduled on one day of the week
+type DayData struct {
+	Name       string // e.g. "Tuesday"
+	Date       time.Time
+	Chores     []ChoreData
+	Difficulty int
+}
+
+// UnassignedData represents a chore that could not be assigned to anyone
+type UnassignedData struct {
+	Name   string
+	Day    string
+	Earned float64
+	Reason string
+}
+
+// FairnessData represents how fair the whole distribution was, and how hard this
+// person worked for what they earned
+type FairnessData struct {
+	EarnedSpread        float64 // the gap between the most and least anyone earned
+	EarnedStdDev        float64
+	Gini                float64 // 0 when everyone earned the same, toward 1 as it gets more uneven
+	DifficultySpread    int
+	DifficultyPerDollar float64 // this person's difficulty per dollar earned, 0 if they earned nothing
+	CapacityUsed        float64 // the share of this person's capacity used, e.g. 0.8, 0 if no limit
 }
 
 // PersonData represents all data for a person's chore assignment
@@ -26,48 +56,114 @@ type PersonData struct {
 	PreAssignedChores []ChoreData
 	DistributedChores []ChoreData
 	AllChores         []ChoreData
+	AnyDayChores      []ChoreData // pre-assigned and distributed chores not scheduled on a day
+	Days              []DayData   // distributed chores scheduled on a day, in date order
 	TotalEarned       float64
+	CarryOver         float64
 	TotalDifficulty   int
 	Capacity          int
+	Seed              uint64
+	Unassigned        []UnassignedData
+	Fairness          FairnessData
 	Verbose           bool
 }
 
-// BuildPersonData converts a models.Person to PersonData for template rendering
-func BuildPersonData(person models.Person, verbose bool) PersonData {
+// BuildPersonData converts a models.Person to PersonData for template rendering.
+// The seed is the one used to generate the distribution (0 if unknown).
+func BuildPersonData(person models.Person, seed uint64, verbose bool) PersonData {
 	data := PersonData{
 		PersonName:      person.Name,
 		Contact:         person.Contact,
 		Date:            time.Now(),
 		TotalEarned:     float64(person.TotalEarned),
+		CarryOver:       float64(person.CarryOver),
 		TotalDifficulty: person.TotalDifficulty,
 		Capacity:        person.EffortCapacity,
+		Seed:            seed,
 		Verbose:         verbose,
 	}
 
 	// Convert pre-assigned chores
 	for _, chore := range person.PreAssignedChores {
-		choreData := ChoreData{
-			Name:        chore.Name,
-			Difficulty:  chore.Difficulty,
-			Earned:      float64(chore.Earned),
-			Description: chore.Description,
-		}
+		choreData := buildChoreData(person.Name, chore)
 		data.PreAssignedChores = append(data.PreAssignedChores, choreData)
 		data.AllChores = append(data.AllChores, choreData)
 	}
 
 	// Convert distributed chores
 	for _, chore := range person.Chores {
-		choreData := ChoreData{
-			Name:        chore.Name,
-			Difficulty:  chore.Difficulty,
-			Earned:      float64(chore.Earned),
-			Description: chore.Description,
-		}
+		choreData := buildChoreData(person.Name, chore)
 		data.DistributedChores = append(data.DistributedChores, choreData)
 		data.AllChores = append(data.AllChores, choreData)
 	}
 
+	// Group them by day for a weekly plan
+	data.AnyDayChores = append(data.AnyDayChores, data.PreAssignedChores...)
+	anyDay, days := models.ByDay(person.Chores)
+	for _, chore := range anyDay {
+		data.AnyDayChores = append(data.AnyDayChores, buildChoreData(person.Name, chore))
+	}
+	for _, day := range days {
+		dayData := DayData{Name: day.Date.Weekday().String(), Date: day.Date}
+		for _, chore := range day.Chores {
+			dayData.Chores = append(dayData.Chores, buildChoreData(person.Name, chore))
+			dayData.Difficulty += chore.Difficulty
+		}
+		data.Days = append(data.Days, dayData)
+	}
+
+	return data
+}
+
+func buildChoreData(person string, chore models.Chore) ChoreData {
+	return ChoreData{
+		Name:        chore.Name,
+		Day:         day(chore),
+		Difficulty:  chore.Difficulty,
+		Earned:      float64(chore.Earned),
+		Surge:       float64(chore.Surge),
+		Description: chore.Description,
+		With:        strings.Join(chore.Teammates(person), ", "),
+	}
+}
+
+// day formats the day a chore is scheduled on, or returns "" if it can be done any day.
+func day(chore models.Chore) string {
+	if chore.Date.IsZero() {
+		return ""
+	}
+	return chore.Date.Format("Mon Jan 2")
+}
+
+// BuildUnassignedData converts unassigned chores for template rendering
+func BuildUnassignedData(unassigned []models.UnassignedChore) []UnassignedData {
+	var data []UnassignedData
+	for _, u := range unassigned {
+		data = append(data, UnassignedData{
+			Name:   u.Chore.Name,
+			Day:    day(u.Chore),
+			Earned: float64(u.Chore.Earned),
+			Reason: u.Reason,
+		})
+	}
+	return data
+}
+
+// BuildFairnessData converts the fairness of a distribution for template rendering,
+// with the measures for the named person
+func BuildFairnessData(fairness models.Fairness, person string) FairnessData {
+	data := FairnessData{
+		EarnedSpread:     float64(fairness.EarnedSpread),
+		EarnedStdDev:     fairness.EarnedStdDev,
+		Gini:             fairness.Gini,
+		DifficultySpread: fairness.DifficultySpread,
+	}
+	for _, p := range fairness.People {
+		if p.Name == person {
+			data.DifficultyPerDollar = p.DifficultyPerDollar
+			data.CapacityUsed = p.CapacityUsed
+		}
+	}
 	return data
 }
 